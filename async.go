@@ -0,0 +1,172 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 16:05
+// version: 1.0.0
+// desc   : 异步输出
+
+package gog
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/yhyzgn/gog/util"
+)
+
+const (
+	queueSize = 1000
+)
+
+// asyncLog 异步队列中的日志，记录由哪个日志处理器输出
+type asyncLog struct {
+	gog  *Gog
+	info *LogInfo
+	done chan struct{} // 不为空时表示同步标记，之前的日志都已输出后关闭
+}
+
+// asyncWorker 异步输出协程，派生的日志处理器共用
+//
+// 开启异步时才启动，关闭日志处理器时退出，退出前会输出队列中剩余的日志
+type asyncWorker struct {
+	mu      sync.RWMutex
+	queue   chan asyncLog
+	running bool          // 是否正在运行
+	stop    chan struct{} // 退出信号
+	exited  chan struct{} // 已退出
+	id      uint64        // 异步输出协程的 id，未运行时为 0
+}
+
+// newAsyncWorker 创建异步输出协程，此时并不启动
+func newAsyncWorker() *asyncWorker {
+	return &asyncWorker{queue: make(chan asyncLog, queueSize)}
+}
+
+// start 启动异步输出协程，已启动时忽略
+func (w *asyncWorker) start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.running {
+		return
+	}
+	w.running = true
+	w.stop = make(chan struct{})
+	w.exited = make(chan struct{})
+	go w.loop(w.stop, w.exited)
+}
+
+// shutdown 通知异步输出协程退出，并等待队列中的日志输出完毕
+func (w *asyncWorker) shutdown() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	close(w.stop)
+	exited := w.exited
+	w.mu.Unlock()
+	if w.onWorker() {
+		// 在异步输出协程中关闭，如输出器内部调用了 Close，等待自身退出会死锁，直接输出剩余的日志
+		w.drain()
+		return
+	}
+	<-exited
+}
+
+// enqueue 添加到异步队列，未运行或队列已满时返回 false，由调用方同步输出
+func (w *asyncWorker) enqueue(item asyncLog) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if !w.running {
+		return false
+	}
+	select {
+	case w.queue <- item:
+		return true
+	default:
+		return false
+	}
+}
+
+// sync 等待队列中已有的日志全部输出
+func (w *asyncWorker) sync() {
+	w.mu.RLock()
+	running, exited := w.running, w.exited
+	w.mu.RUnlock()
+	if !running {
+		return
+	}
+	if w.onWorker() {
+		// 在异步输出协程中同步，如输出器打印了 FATAL 日志，同步标记永远不会被处理，直接输出队列中的日志
+		w.drain()
+		return
+	}
+
+	done := make(chan struct{})
+	select {
+	case w.queue <- asyncLog{done: done}:
+	case <-exited:
+		return
+	}
+	select {
+	case <-done:
+	case <-exited:
+	}
+}
+
+// loop 依次输出队列中的日志，收到退出信号后输出剩余的日志再退出
+func (w *asyncWorker) loop(stop, exited chan struct{}) {
+	atomic.StoreUint64(&w.id, util.GoroutineID())
+	defer close(exited)
+	defer atomic.StoreUint64(&w.id, 0)
+	for {
+		select {
+		case item := <-w.queue:
+			w.handle(item)
+		case <-stop:
+			w.drain()
+			return
+		}
+	}
+}
+
+// drain 输出队列中当前的全部日志
+func (w *asyncWorker) drain() {
+	for {
+		select {
+		case item := <-w.queue:
+			w.handle(item)
+		default:
+			return
+		}
+	}
+}
+
+// onWorker 当前是否运行在异步输出协程中
+func (w *asyncWorker) onWorker() bool {
+	id := atomic.LoadUint64(&w.id)
+	return id != 0 && id == util.GoroutineID()
+}
+
+// handle 输出一条日志或响应同步标记
+func (w *asyncWorker) handle(item asyncLog) {
+	if item.done != nil {
+		close(item.done)
+		return
+	}
+	item.gog.out(item.info)
+}
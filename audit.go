@@ -0,0 +1,364 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 09:50
+// version: 1.0.0
+// desc   : 防篡改审计日志
+
+package gog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// auditGenesis 第一条审计记录的 prev 值
+var auditGenesis = hex.EncodeToString(make([]byte, sha256.Size))
+
+// ErrAuditIncomplete 审计日志末尾有未以换行结束的内容，通常是写入过程中进程退出，
+// 需要人工确认并修复后才能继续写入，输出器不会修改已有内容
+var ErrAuditIncomplete = errors.New("gog: audit log ends with an incomplete record")
+
+// auditSigKey 签名字段，总是位于记录的最后
+const auditSigKey = `,"sig":"`
+
+// AuditSigner 审计记录签名
+type AuditSigner interface {
+	Sign(payload []byte) ([]byte, error)
+}
+
+// AuditVerifier 审计记录验签
+type AuditVerifier interface {
+	Verify(payload, sig []byte) bool
+}
+
+// HMACAuditKey 使用 HMAC-SHA256 签名和验签
+type HMACAuditKey []byte
+
+// Sign 签名
+func (key HMACAuditKey) Sign(payload []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil), nil
+}
+
+// Verify 验签
+func (key HMACAuditKey) Verify(payload, sig []byte) bool {
+	expected, _ := key.Sign(payload)
+	return hmac.Equal(expected, sig)
+}
+
+// Ed25519AuditSigner 使用 ed25519 私钥签名
+type Ed25519AuditSigner ed25519.PrivateKey
+
+// Sign 签名
+func (key Ed25519AuditSigner) Sign(payload []byte) ([]byte, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, errors.New("gog: invalid ed25519 private key")
+	}
+	return ed25519.Sign(ed25519.PrivateKey(key), payload), nil
+}
+
+// Ed25519AuditVerifier 使用 ed25519 公钥验签
+type Ed25519AuditVerifier ed25519.PublicKey
+
+// Verify 验签
+func (key Ed25519AuditVerifier) Verify(payload, sig []byte) bool {
+	return len(key) == ed25519.PublicKeySize && ed25519.Verify(ed25519.PublicKey(key), payload, sig)
+}
+
+// AuditWriter 防篡改审计日志输出器
+//
+// 每条日志写为一行 json，带有递增的序号 seq 和上一行的 SHA-256 摘要 prev，
+// 设置了 AuditSigner 时还会对整行签名；每次写入都会同步落盘，写入成功后才返回。
+// 重新打开已有文件时会从最后一行继续链接，末尾未写完的记录（如进程崩溃）会被截断。
+// 审计日志应使用同步模式的日志处理器输出
+type AuditWriter struct {
+	mu     sync.Mutex
+	file   auditFile
+	signer AuditSigner
+	seq    uint64 // 上一条记录的序号
+	prev   string // 上一条记录的摘要
+	size   int64  // 已写入的完整记录的字节数
+}
+
+// auditFile 审计日志文件
+type auditFile interface {
+	io.WriteCloser
+	Sync() error
+	Truncate(size int64) error
+}
+
+// AuditError 审计日志校验失败的位置和原因
+type AuditError struct {
+	Line   int    // 行号，从 1 开始
+	Seq    uint64 // 该行记录的序号，无法解析时为 0
+	Reason string // 原因
+}
+
+// Error 实现 error
+func (e *AuditError) Error() string {
+	return fmt.Sprintf("gog: audit chain broken at line %d (seq %d): %s", e.Line, e.Seq, e.Reason)
+}
+
+// NewAuditWriter 创建审计日志输出器，signer 为 nil 时不签名
+//
+// 已有的审计日志末尾没有以换行结束时返回 ErrAuditIncomplete
+func NewAuditWriter(path string, signer AuditSigner) (*AuditWriter, error) {
+	aw := &AuditWriter{signer: signer, prev: auditGenesis}
+	last, end, size, err := readLastLine(path)
+	if err != nil {
+		return nil, err
+	}
+	if end < size {
+		return nil, fmt.Errorf("%w: %s has %d trailing bytes", ErrAuditIncomplete, path, size-end)
+	}
+	if len(last) > 0 {
+		var head struct {
+			Seq uint64 `json:"seq"`
+		}
+		if err = json.Unmarshal(last, &head); err != nil {
+			return nil, fmt.Errorf("gog: invalid audit log %s: %v", path, err)
+		}
+		aw.seq, aw.prev = head.Seq, auditDigest(last)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	aw.file, aw.size = file, size
+	return aw, nil
+}
+
+// Write 写入一条审计记录并同步落盘
+//
+// 记录完整写入文件后即链接到下一条记录，之后同步失败只返回错误；
+// 只写入了一部分时截断回写入前的位置，截断失败时关闭输出器，避免在不完整的记录之后继续写入
+func (aw *AuditWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	if aw.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	line, err := aw.encode(info, aw.seq+1, aw.prev)
+	if err != nil {
+		return 0, err
+	}
+	written, err := aw.file.Write(append(line, '\n'))
+	if err != nil {
+		if written > 0 {
+			if terr := aw.file.Truncate(aw.size); terr != nil {
+				_ = aw.file.Close()
+				aw.file = nil
+				return 0, fmt.Errorf("gog: audit write failed: %v, truncate failed: %v", err, terr)
+			}
+		}
+		return 0, err
+	}
+	aw.size += int64(written)
+	aw.seq++
+	aw.prev = auditDigest(line)
+	if err = aw.file.Sync(); err != nil {
+		return len(data), err
+	}
+	return len(data), nil
+}
+
+// Close 关闭审计日志文件
+func (aw *AuditWriter) Close() error {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	if aw.file == nil {
+		return nil
+	}
+	err := aw.file.Close()
+	aw.file = nil
+	return err
+}
+
+// encode 编码一条审计记录
+func (aw *AuditWriter) encode(info *LogInfo, seq uint64, prev string) ([]byte, error) {
+	enc := getJSONEncoder()
+	defer putJSONEncoder(enc)
+
+	enc.openObject()
+	enc.key("seq")
+	enc.appendUint(seq)
+	enc.key("prev")
+	enc.appendString(prev)
+	enc.key("time")
+	enc.appendString(info.Time.Format(time.RFC3339Nano))
+	enc.key("level")
+	enc.appendString(GetLevelName(info.Level))
+	if info.Tag != "" {
+		enc.key("tag")
+		enc.appendString(info.Tag)
+	}
+	if info.File != "" {
+		enc.key("caller")
+		enc.appendString(info.File + ":" + strconv.Itoa(info.Line))
+		enc.key("func")
+		enc.appendString(info.Func)
+	}
+	enc.key("message")
+	enc.appendString(info.Body)
+	if len(info.Fields) > 0 {
+		enc.key("fields")
+		enc.openObject()
+		for _, field := range info.Fields {
+			enc.key(field.Key)
+			if err := field.appendJSON(enc); err != nil {
+				return nil, err
+			}
+		}
+		enc.closeObject()
+	}
+	enc.closeObject()
+
+	line := append([]byte{}, enc.buf...)
+	if aw.signer == nil {
+		return line, nil
+	}
+	sig, err := aw.signer.Sign(line)
+	if err != nil {
+		return nil, err
+	}
+	line = append(line[:len(line)-1], auditSigKey...)
+	line = append(line, base64.StdEncoding.EncodeToString(sig)...)
+	return append(line, '"', '}'), nil
+}
+
+// VerifyAuditLog 校验审计日志，返回校验通过的记录数
+//
+// 遇到第一处断链、序号不连续或签名无效时返回 *AuditError；verifier 为 nil 时不校验签名
+func VerifyAuditLog(r io.Reader, verifier AuditVerifier) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	var (
+		count int
+		seq   uint64
+		prev  = auditGenesis
+	)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		num := count + 1
+
+		var head struct {
+			Seq  uint64 `json:"seq"`
+			Prev string `json:"prev"`
+			Sig  string `json:"sig"`
+		}
+		if err := json.Unmarshal(line, &head); err != nil {
+			return count, &AuditError{Line: num, Reason: "invalid record: " + err.Error()}
+		}
+		if head.Seq != seq+1 {
+			return count, &AuditError{Line: num, Seq: head.Seq, Reason: fmt.Sprintf("sequence gap, want %d", seq+1)}
+		}
+		if head.Prev != prev {
+			return count, &AuditError{Line: num, Seq: head.Seq, Reason: "previous hash mismatch"}
+		}
+		if verifier != nil {
+			idx := bytes.LastIndex(line, []byte(auditSigKey))
+			sig, err := base64.StdEncoding.DecodeString(head.Sig)
+			if idx < 0 || head.Sig == "" || err != nil {
+				return count, &AuditError{Line: num, Seq: head.Seq, Reason: "missing signature"}
+			}
+			payload := append(append([]byte{}, line[:idx]...), '}')
+			if !verifier.Verify(payload, sig) {
+				return count, &AuditError{Line: num, Seq: head.Seq, Reason: "invalid signature"}
+			}
+		}
+
+		seq, prev = head.Seq, auditDigest(line)
+		count++
+	}
+	return count, scanner.Err()
+}
+
+// auditDigest 记录的 SHA-256 摘要
+func auditDigest(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:])
+}
+
+// readLastLine 读取文件中最后一条完整的记录
+//
+// end 为最后一个换行之后的偏移，size 为文件大小，end < size 时末尾为未写完的记录
+func readLastLine(path string) (last []byte, end, size int64, err error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 0, 0, nil
+	}
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	size, end = stat.Size(), -1
+	// 从文件末尾向前按块查找
+	var (
+		chunk = int64(4096)
+		tail  []byte
+	)
+	for offset := size; offset > 0; {
+		n := chunk
+		if offset < n {
+			n = offset
+		}
+		offset -= n
+		buf := make([]byte, n)
+		if _, err = file.ReadAt(buf, offset); err != nil {
+			return nil, 0, 0, err
+		}
+		tail = append(buf, tail...)
+		if end < 0 {
+			idx := bytes.LastIndexByte(tail, '\n')
+			if idx < 0 {
+				continue
+			}
+			end = offset + int64(idx) + 1
+		}
+		complete := bytes.TrimRight(tail[:end-offset], "\n")
+		if idx := bytes.LastIndexByte(complete, '\n'); idx >= 0 {
+			return complete[idx+1:], end, size, nil
+		}
+		if offset == 0 {
+			return complete, end, size, nil
+		}
+	}
+	// 文件为空或没有完整的记录
+	return nil, 0, size, nil
+}
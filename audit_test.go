@@ -0,0 +1,199 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 09:50
+// version: 1.0.0
+// desc   : 防篡改审计日志
+
+package gog
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	key := HMACAuditKey("secret")
+	write := func(bodies ...string) {
+		aw, err := NewAuditWriter(path, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, body := range bodies {
+			info := &LogInfo{Time: time.Now(), Level: INFO, Tag: "audit", Body: body, Fields: []Field{String("user", "alice")}}
+			if _, err = aw.Write(info, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_ = aw.Close()
+	}
+	write("login", "grant")
+	// 重新打开后继续链接
+	write("revoke")
+
+	data, _ := os.ReadFile(path)
+	if n, err := VerifyAuditLog(bytes.NewReader(data), key); err != nil || n != 3 {
+		t.Fatalf("verify = %d, %v", n, err)
+	}
+
+	tampered := bytes.Replace(data, []byte(`"grant"`), []byte(`"admin"`), 1)
+	var auditErr *AuditError
+	if _, err := VerifyAuditLog(bytes.NewReader(tampered), key); !errors.As(err, &auditErr) || auditErr.Line != 2 {
+		t.Fatalf("tampered signature = %v", err)
+	}
+	if _, err := VerifyAuditLog(bytes.NewReader(tampered), nil); !errors.As(err, &auditErr) || auditErr.Line != 3 {
+		t.Fatalf("tampered chain = %v", err)
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	removed := append(append([]byte{}, lines[0]...), lines[2]...)
+	if _, err := VerifyAuditLog(bytes.NewReader(removed), nil); !errors.As(err, &auditErr) || auditErr.Seq != 3 {
+		t.Fatalf("removed record = %v", err)
+	}
+}
+
+func TestAuditEd25519(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	aw, err := NewAuditWriter(path, Ed25519AuditSigner(priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = aw.Write(&LogInfo{Level: WARN, Body: "delete"}, nil)
+	_ = aw.Close()
+
+	file, _ := os.Open(path)
+	defer file.Close()
+	if n, err := VerifyAuditLog(file, Ed25519AuditVerifier(pub)); err != nil || n != 1 {
+		t.Fatalf("verify = %d, %v", n, err)
+	}
+}
+
+// faultyAuditFile 模拟写入和同步失败的审计日志文件
+type faultyAuditFile struct {
+	*os.File
+	failSync    bool // 下次同步失败
+	shortWrites bool // 下次写入只写一半
+}
+
+func (f *faultyAuditFile) Write(p []byte) (int, error) {
+	if f.shortWrites {
+		f.shortWrites = false
+		n, _ := f.File.Write(p[:len(p)/2])
+		return n, errors.New("disk full")
+	}
+	return f.File.Write(p)
+}
+
+func (f *faultyAuditFile) Sync() error {
+	if f.failSync {
+		f.failSync = false
+		return errors.New("sync failed")
+	}
+	return f.File.Sync()
+}
+
+func TestAuditWriterFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	aw, err := NewAuditWriter(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &faultyAuditFile{File: aw.file.(*os.File)}
+	aw.file = file
+	info := &LogInfo{Time: time.Now(), Level: INFO, Body: "event"}
+
+	// 同步失败时记录已写入，链接继续
+	file.failSync = true
+	if _, err = aw.Write(info, nil); err == nil {
+		t.Fatal("sync failure should be reported")
+	}
+	// 只写入一部分时截断
+	file.shortWrites = true
+	if _, err = aw.Write(info, nil); err == nil {
+		t.Fatal("short write should be reported")
+	}
+	if _, err = aw.Write(info, nil); err != nil {
+		t.Fatal(err)
+	}
+	_ = aw.Close()
+
+	data, _ := os.ReadFile(path)
+	if n, err := VerifyAuditLog(bytes.NewReader(data), nil); err != nil || n != 2 {
+		t.Fatalf("verify = %d, %v\n%s", n, err, data)
+	}
+}
+
+func TestAuditWriterPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	key := HMACAuditKey("secret")
+	write := func(body string) {
+		aw, err := NewAuditWriter(path, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = aw.Write(&LogInfo{Time: time.Now(), Level: INFO, Body: body}, nil); err != nil {
+			t.Fatal(err)
+		}
+		_ = aw.Close()
+	}
+	appendRaw := func(raw string) {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = file.WriteString(raw)
+		_ = file.Close()
+	}
+
+	write("first")
+	write("second")
+	// 崩溃时最后一条记录没有写完，已有内容不能被修改
+	appendRaw(`{"seq":3,"prev":"ab`)
+	before, _ := os.ReadFile(path)
+	if _, err := NewAuditWriter(path, key); !errors.Is(err, ErrAuditIncomplete) {
+		t.Fatalf("incomplete audit log: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !bytes.Equal(data, before) {
+		t.Fatalf("audit log modified:\n%s", data)
+	}
+	if n, err := VerifyAuditLog(bytes.NewReader(before[:bytes.LastIndexByte(before, '\n')+1]), key); err != nil || n != 2 {
+		t.Fatalf("verify = %d, %v", n, err)
+	}
+
+	// 没有换行的已有文件同样不会被截断
+	other := filepath.Join(t.TempDir(), "other.log")
+	_ = os.WriteFile(other, []byte("not an audit log"), 0600)
+	if _, err := NewAuditWriter(other, key); !errors.Is(err, ErrAuditIncomplete) {
+		t.Fatalf("file without newline: %v", err)
+	}
+	if data, _ := os.ReadFile(other); string(data) != "not an audit log" {
+		t.Fatalf("file modified: %q", data)
+	}
+
+	path = filepath.Join(t.TempDir(), "audit.log")
+	write("first")
+	// 完整但无法解析的记录仍然报错
+	appendRaw("garbage\n")
+	if _, err := NewAuditWriter(path, key); err == nil {
+		t.Fatal("corrupted audit log should fail")
+	}
+}
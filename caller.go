@@ -0,0 +1,133 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-21 15:20
+// version: 1.0.0
+// desc   : 调用源信息
+
+package gog
+
+import (
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/yhyzgn/gog/util"
+)
+
+// CallerMode 发生地文件的显示方式
+type CallerMode int
+
+// 发生地文件的显示方式
+const (
+	CallerShort   CallerMode = iota // 只显示文件名，如 "runtime.go"
+	CallerPackage                   // 包目录加文件名，如 "util/runtime.go"
+	CallerModule                    // 相对模块根目录的路径，如 "util/runtime.go"
+	CallerFull                      // 完整路径
+)
+
+// FuncMode 发生地函数的显示方式
+type FuncMode int
+
+// 发生地函数的显示方式
+const (
+	FuncShort     FuncMode = iota // 只显示函数名，如 "Write"，闭包显示为 "func1"
+	FuncQualified                 // 包名加完整函数名，如 "gog.(*Gog).Write"
+	FuncFull                      // 完整包路径加函数名，如 "github.com/yhyzgn/gog.(*Gog).Write"
+)
+
+var (
+	wrapperMu       sync.RWMutex
+	wrapperPackages = make(map[string]struct{}) // 注册的封装包路径
+	helpers         sync.Map                    // 通过 Helper 标记的封装函数
+)
+
+// Helper 将调用该方法的函数标记为封装函数，定位调用源时自动跳过其栈帧
+//
+// 与 testing.T.Helper 类似，在封装了 gog 的函数开头调用即可
+func Helper() {
+	var pcs [1]uintptr
+	if runtime.Callers(2, pcs[:]) == 0 {
+		return
+	}
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
+	if _, ok := helpers.Load(frame.Function); !ok {
+		helpers.Store(frame.Function, struct{}{})
+	}
+}
+
+// RegisterWrapperPackage 注册封装了 gog 的包，定位调用源时自动跳过这些包中的栈帧
+//
+// 参数为包的导入路径，如 "github.com/foo/bar/logger"
+func RegisterWrapperPackage(pkgPath ...string) {
+	wrapperMu.Lock()
+	defer wrapperMu.Unlock()
+	for _, pkg := range pkgPath {
+		wrapperPackages[pkg] = struct{}{}
+	}
+}
+
+// unregisterWrapperPackage 取消注册封装包，用于测试还原全局状态
+func unregisterWrapperPackage(pkgPath ...string) {
+	wrapperMu.Lock()
+	defer wrapperMu.Unlock()
+	for _, pkg := range pkgPath {
+		delete(wrapperPackages, pkg)
+	}
+}
+
+// skipCallerFrame 判断栈帧是否属于封装函数或注册的封装包
+func skipCallerFrame(function string) bool {
+	if _, ok := helpers.Load(function); ok {
+		return true
+	}
+	return isWrapperFrame(function)
+}
+
+// isWrapperFrame 判断栈帧是否属于注册的封装包
+func isWrapperFrame(function string) bool {
+	wrapperMu.RLock()
+	defer wrapperMu.RUnlock()
+	if len(wrapperPackages) == 0 {
+		return false
+	}
+	_, ok := wrapperPackages[util.PackagePath(function)]
+	return ok
+}
+
+// callerFile 按显示方式处理文件路径
+func callerFile(frame *runtime.Frame, mode CallerMode) string {
+	switch mode {
+	case CallerShort:
+		return filepath.Base(frame.File)
+	case CallerPackage:
+		return util.PackageRelativePath(frame.File)
+	case CallerModule:
+		return util.ModuleRelativePath(frame.File, frame.Function)
+	}
+	return frame.File
+}
+
+// callerFunc 按显示方式处理函数名
+func callerFunc(frame *runtime.Frame, mode FuncMode) string {
+	switch mode {
+	case FuncQualified:
+		return util.QualifiedFuncName(frame.Function)
+	case FuncFull:
+		return frame.Function
+	}
+	return util.ShortFuncName(frame.Function)
+}
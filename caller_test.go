@@ -0,0 +1,153 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-21 15:20
+// version: 1.0.0
+// desc   : 调用源信息测试
+
+package gog
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// logCaller 输出一条日志并返回调用处的行号
+func logCaller(g *Gog) int {
+	_, _, line, _ := runtime.Caller(0)
+	g.Info("caller")
+	return line + 1
+}
+
+func TestCallerModes(t *testing.T) {
+	_, file, _, _ := runtime.Caller(0)
+	cases := []struct {
+		callerMode CallerMode
+		funcMode   FuncMode
+		file       string
+		function   string
+	}{
+		{CallerShort, FuncShort, "caller_test.go", "logCaller"},
+		{CallerPackage, FuncQualified, filepath.Join(filepath.Base(filepath.Dir(file)), "caller_test.go"), "gog.logCaller"},
+		{CallerModule, FuncFull, "caller_test.go", "github.com/yhyzgn/gog.logCaller"},
+		{CallerFull, FuncShort, file, "logCaller"},
+	}
+	for _, c := range cases {
+		rw := NewRingWriter(1)
+		g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{rw}}).
+			CallerMode(c.callerMode).FuncMode(c.funcMode)
+		line := logCaller(g)
+		info := rw.Records()[0]
+		if info.File != c.file || info.Func != c.function || info.Line != line {
+			t.Errorf("mode %d/%d: got %s:%d (%s), want %s:%d (%s)", c.callerMode, c.funcMode, info.File, info.Line, info.Func, c.file, line, c.function)
+		}
+	}
+}
+
+func TestRegisterWrapperPackage(t *testing.T) {
+	const function = "gopkg.in/yaml%2ev2.(*Encoder).Encode"
+	if skipCallerFrame(function) {
+		t.Fatal("unregistered package skipped")
+	}
+	RegisterWrapperPackage("gopkg.in/yaml.v2")
+	t.Cleanup(func() {
+		unregisterWrapperPackage("gopkg.in/yaml.v2")
+	})
+	if !skipCallerFrame(function) {
+		t.Fatal("registered package with escaped dot not skipped")
+	}
+	if skipCallerFrame("gopkg.in/yaml%2ev3.Marshal") || skipCallerFrame("gopkg.in/yaml.v2/sub.Marshal") {
+		t.Fatal("other packages skipped")
+	}
+}
+
+// logSkip 多封装了一层的日志函数，通过 WithCallerSkip 跳过自身
+func logSkip(g *Gog, body string) {
+	g.WithCallerSkip(1).Info(body)
+}
+
+// logHelper 通过 Helper 标记为封装函数的日志函数
+func logHelper(g *Gog, body string) {
+	Helper()
+	g.Info(body)
+}
+
+// nextLine 返回调用处的下一行行号
+func nextLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line + 1
+}
+
+func assertCaller(t *testing.T, info LogInfo, body string, line int) {
+	t.Helper()
+	if info.Body != body || info.File != "caller_test.go" || info.Line != line {
+		t.Errorf("%s: got %s:%d (%s), want caller_test.go:%d", body, info.File, info.Line, info.Body, line)
+	}
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	rw := NewRingWriter(8)
+	g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{rw}}).CallerMode(CallerShort)
+
+	var lines []int
+	lines = append(lines, nextLine())
+	g.WithCallerSkip(0).Info("skip 0")
+	lines = append(lines, nextLine())
+	logSkip(g, "skip 1")
+	lines = append(lines, nextLine())
+	logHelper(g, "helper")
+	lines = append(lines, nextLine())
+	logHelper(g.WithCallerSkip(0), "derived helper")
+
+	records := rw.Records()
+	if len(records) != len(lines) {
+		t.Fatalf("records = %d, want %d", len(records), len(lines))
+	}
+	for i, body := range []string{"skip 0", "skip 1", "helper", "derived helper"} {
+		assertCaller(t, records[i], body, lines[i])
+	}
+}
+
+func TestDefaultGogCallerSkip(t *testing.T) {
+	rw := NewRingWriter(8)
+	cfg := gog.config
+	SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{rw}})
+	defer SetConfig(cfg)
+
+	var lines []int
+	lines = append(lines, nextLine())
+	Warn("package")
+	lines = append(lines, nextLine())
+	GetGog().WithCallerSkip(0).Warn("derived")
+	lines = append(lines, nextLine())
+	logSkip(GetGog(), "derived skip 1")
+	lines = append(lines, nextLine())
+	logHelper(GetGog(), "default helper")
+	lines = append(lines, nextLine())
+	NewScope(ERROR).Warn("scope")
+	if err := Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	records := rw.Records()
+	if len(records) != len(lines) {
+		t.Fatalf("records = %d, want %d", len(records), len(lines))
+	}
+	for i, body := range []string{"package", "derived", "derived skip 1", "default helper", "scope"} {
+		assertCaller(t, records[i], body, lines[i])
+	}
+}
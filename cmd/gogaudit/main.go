@@ -0,0 +1,115 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 09:50
+// version: 1.0.0
+// desc   : gogaudit 审计日志校验工具
+//
+// 逐个校验审计日志文件，报告第一处断链：
+//
+//	gogaudit audit.log                          # 只校验哈希链
+//	gogaudit -hmac-key key.txt audit.log        # 同时校验 HMAC 签名
+//	gogaudit -ed25519-pub pub.key audit.log     # 同时校验 ed25519 签名
+
+package main
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/yhyzgn/gog"
+)
+
+func main() {
+	var (
+		hmacKey = flag.String("hmac-key", "", "HMAC 密钥文件")
+		pubKey  = flag.String("ed25519-pub", "", "ed25519 公钥文件")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "用法: %s [选项] 文件 ...\n\n密钥文件可以是原始字节、十六进制或 base64 文本\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || *hmacKey != "" && *pubKey != "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var verifier gog.AuditVerifier
+	switch {
+	case *hmacKey != "":
+		key, err := readKey(*hmacKey)
+		if err != nil {
+			fatal(err)
+		}
+		verifier = gog.HMACAuditKey(key)
+	case *pubKey != "":
+		key, err := readKey(*pubKey)
+		if err != nil {
+			fatal(err)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			fatal(fmt.Errorf("invalid ed25519 public key size %d", len(key)))
+		}
+		verifier = gog.Ed25519AuditVerifier(key)
+	}
+
+	broken := false
+	for _, path := range flag.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			fatal(err)
+		}
+		n, err := gog.VerifyAuditLog(file, verifier)
+		_ = file.Close()
+		if err != nil {
+			broken = true
+			fmt.Printf("%s: BROKEN after %d records: %v\n", path, n, err)
+			continue
+		}
+		fmt.Printf("%s: OK, %d records\n", path, n)
+	}
+	if broken {
+		os.Exit(1)
+	}
+}
+
+// readKey 读取密钥文件，依次尝试十六进制、base64 和原始字节
+func readKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := string(bytes.TrimSpace(data))
+	if key, err := hex.DecodeString(text); err == nil && text != "" {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && text != "" {
+		return key, nil
+	}
+	return data, nil
+}
+
+// fatal 输出错误并退出
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "gogaudit:", err)
+	os.Exit(2)
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 16:20
+// version: 1.0.0
+// desc   : 读取与跟踪日志文件
+
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// pollInterval 跟踪文件时检查新内容的间隔
+const pollInterval = 250 * time.Millisecond
+
+// openReader 打开文件，gzip 压缩的文件自动解压
+func openReader(file *os.File) (*bufio.Reader, bool, error) {
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, true, err
+		}
+		return bufio.NewReader(gz), true, nil
+	}
+	return reader, false, nil
+}
+
+// readAll 读取全部内容，每行交给 emit 处理
+func readAll(reader *bufio.Reader, emit func(line string)) error {
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			emit(line)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readStdin 读取标准输入
+func readStdin(emit func(line string), idle func()) error {
+	reader, _, err := openReader(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer idle()
+	return readAll(reader, emit)
+}
+
+// readFile 读取文件，follow 为 true 时持续跟踪新增内容，直到 stop 被关闭
+//
+// 跟踪时文件被轮转（重命名后新建）会读完旧文件再切换到新文件，文件被截断时从头读取；
+// gzip 压缩的文件不会再增长，读完即结束
+func readFile(path string, follow bool, stop <-chan struct{}, emit func(line string), idle func()) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader, compressed, err := openReader(file)
+	if err != nil {
+		return err
+	}
+	if !follow || compressed {
+		defer idle()
+		return readAll(reader, emit)
+	}
+
+	var (
+		partial string // 尚未写完的行
+		offset  int64  // 已读取的字节数
+	)
+	for {
+		line, err := reader.ReadString('\n')
+		offset += int64(len(line))
+		partial += line
+		if err == nil {
+			emit(partial)
+			partial = ""
+			continue
+		}
+		if err != io.EOF {
+			return err
+		}
+
+		idle()
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(pollInterval):
+		}
+
+		stat, err := os.Stat(path)
+		if err != nil {
+			// 轮转过程中文件可能暂时不存在
+			continue
+		}
+		current, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		switch {
+		case !os.SameFile(stat, current):
+			// 文件已轮转，读完旧文件剩余内容后切换，旧文件不会再写入，末尾不完整的行也一并输出
+			if err = readAll(reader, func(line string) {
+				partial += line
+				if strings.HasSuffix(line, "\n") {
+					emit(partial)
+					partial = ""
+				}
+			}); err != nil {
+				return err
+			}
+			if partial != "" {
+				emit(partial)
+				partial = ""
+			}
+			next, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			_ = file.Close()
+			file, offset = next, 0
+			reader.Reset(file)
+		case stat.Size() < offset:
+			// 文件被截断
+			if _, err = file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			partial, offset = "", 0
+			reader.Reset(file)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 16:20
+// version: 1.0.0
+// desc   : 跟踪文件测试
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(path, []byte("a1\na2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := make(chan string, 16)
+	rotated := false
+	// 首次读到文件末尾时，追加内容后立即轮转，追加的内容只能在轮转时读取
+	rotate := func() {
+		if rotated {
+			return
+		}
+		rotated = true
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_, _ = file.WriteString("a3\na4\na5")
+		_ = file.Close()
+		if err = os.Rename(path, path+".1"); err != nil {
+			t.Error(err)
+		}
+		if err = os.WriteFile(path, []byte("b1\nb2\n"), 0644); err != nil {
+			t.Error(err)
+		}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- readFile(path, true, stop, func(line string) {
+			lines <- strings.TrimSuffix(line, "\n")
+		}, rotate)
+	}()
+
+	want := []string{"a1", "a2", "a3", "a4", "a5", "b1", "b2"}
+	var got []string
+	timeout := time.After(5 * time.Second)
+	for len(got) < len(want) {
+		select {
+		case line := <-lines:
+			got = append(got, line)
+		case <-timeout:
+			t.Fatalf("lines = %q, want %q", got, want)
+		}
+	}
+	close(stop)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+}
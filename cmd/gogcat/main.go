@@ -0,0 +1,167 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 16:20
+// version: 1.0.0
+// desc   : gogcat 日志查看与格式转换工具
+//
+// 读取 json、normal 或 logfmt 格式的 gog 日志，按 NormalFormatter 的颜色渲染或转换为其他格式：
+//
+//	gogcat app.log                # 彩色渲染
+//	gogcat -o logfmt app.log.gz   # 转换为 logfmt，自动解压
+//	gogcat -f app.log             # 跟踪文件新增内容，支持轮转
+//	cat app.log | gogcat -o json  # 从标准输入读取
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/yhyzgn/gog"
+)
+
+func main() {
+	var (
+		output = flag.String("o", "normal", "输出格式：normal、json、logfmt")
+		color  = flag.String("color", "auto", "是否输出颜色：auto、always、never")
+		follow = flag.Bool("f", false, "跟踪文件新增内容")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "用法: %s [选项] [文件 ...]\n\n未指定文件或文件为 - 时读取标准输入\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	ftr, err := newFormatter(*output, *color)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gogcat:", err)
+		os.Exit(2)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	var mu sync.Mutex
+	render := func(records []*record) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, rec := range records {
+			if rec.info == nil {
+				_, _ = out.WriteString(rec.raw + "\n")
+				continue
+			}
+			data, err := ftr.Format(rec.info.Level, rec.levelName, rec.info)
+			if err != nil {
+				_, _ = out.WriteString(rec.raw + "\n")
+				continue
+			}
+			_, _ = out.Write(data)
+		}
+	}
+	// 每个来源单独解析，调用栈续行不会串到其他文件
+	source := func() (func(string), func()) {
+		p := &parser{}
+		return func(line string) {
+				render(p.feed(line))
+			}, func() {
+				render(p.flush())
+				mu.Lock()
+				_ = out.Flush()
+				mu.Unlock()
+			}
+	}
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var (
+		wg     sync.WaitGroup
+		failed bool
+	)
+	for _, path := range files {
+		run := func(path string) {
+			var err error
+			emit, idle := source()
+			if path == "-" {
+				err = readStdin(emit, idle)
+			} else {
+				err = readFile(path, *follow, nil, emit, idle)
+			}
+			if err != nil {
+				mu.Lock()
+				failed = true
+				fmt.Fprintln(os.Stderr, "gogcat:", err)
+				mu.Unlock()
+			}
+		}
+		if *follow {
+			wg.Add(1)
+			go func(path string) {
+				defer wg.Done()
+				run(path)
+			}(path)
+		} else {
+			run(path)
+		}
+	}
+	wg.Wait()
+
+	if failed {
+		_ = out.Flush()
+		os.Exit(1)
+	}
+}
+
+// newFormatter 创建输出格式化
+//
+// normal 格式按 color 固定是否上色：always 强制上色，只有 auto 会参考 NO_COLOR、FORCE_COLOR 和终端检测
+func newFormatter(output, color string) (gog.Formatter, error) {
+	switch output {
+	case "normal":
+		var colorful bool
+		switch color {
+		case "auto":
+			colorful = gog.ColorEnabled(os.Stdout)
+		case "always":
+			colorful = true
+		case "never":
+		default:
+			return nil, fmt.Errorf("unknown color mode %q", color)
+		}
+		return colorFormatter{ftr: gog.NewNormalColorfulFormatter(), colorful: colorful}, nil
+	case "json":
+		return gog.NewJSONFormatter(), nil
+	case "logfmt":
+		return gog.NewLogfmtFormatter(), nil
+	}
+	return nil, fmt.Errorf("unknown output format %q", output)
+}
+
+// colorFormatter 按固定的颜色设置格式化，不再由 Format 检查环境变量
+type colorFormatter struct {
+	ftr      gog.ColorFormatter
+	colorful bool
+}
+
+// Format 实现 gog.Formatter
+func (cf colorFormatter) Format(level gog.Level, levelName string, info *gog.LogInfo) ([]byte, error) {
+	return cf.ftr.FormatColor(level, levelName, info, cf.colorful)
+}
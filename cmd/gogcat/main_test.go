@@ -0,0 +1,50 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 16:20
+// version: 1.0.0
+// desc   : gogcat 日志查看与格式转换工具
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/yhyzgn/gog"
+)
+
+func TestNewFormatterColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	t.Setenv("FORCE_COLOR", "")
+	info := &gog.LogInfo{Level: gog.INFO, Body: "hello"}
+	for mode, want := range map[string]bool{"always": true, "auto": false, "never": false} {
+		ftr, err := newFormatter("normal", mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ftr.Format(gog.INFO, "INFO", info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := bytes.Contains(data, []byte("\x1b[")); got != want {
+			t.Errorf("-color %s with NO_COLOR: colorful = %v, want %v: %q", mode, got, want, data)
+		}
+	}
+	if _, err := newFormatter("normal", "sometimes"); err == nil {
+		t.Error("unknown color mode should fail")
+	}
+}
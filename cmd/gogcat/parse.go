@@ -0,0 +1,402 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 16:20
+// version: 1.0.0
+// desc   : 日志行解析
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yhyzgn/gog"
+	"github.com/yhyzgn/gog/util"
+)
+
+// record 解析后的一条日志，无法解析的行原样保存在 raw 中
+type record struct {
+	info      *gog.LogInfo
+	levelName string
+	raw       string
+}
+
+var (
+	// normal 格式行首：时间、级别
+	normalPrefix = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d+)?)\s+(\S+)\s+`)
+	// normal 格式发生地：文件:行号
+	normalCaller = regexp.MustCompile(`^(\S*):(\d+) *\(`)
+	// json 格式合并的发生地："file:line (func)"
+	jsonCaller = regexp.MustCompile(`^(.*):(\d+) \((.*)\)$`)
+)
+
+// parser 日志行解析器
+//
+// 支持 json、normal 和 logfmt 格式，normal 格式中以制表符开头的调用栈续行会合并到上一条日志
+type parser struct {
+	pending *record
+}
+
+// feed 解析一行日志，返回已完整的日志
+func (p *parser) feed(line string) []*record {
+	line = strings.TrimRight(string(util.StripANSI([]byte(line))), "\r\n")
+
+	if strings.HasPrefix(line, "\t") && p.pending != nil && p.pending.info != nil {
+		p.pending.raw += "\n" + line
+		return nil
+	}
+
+	done := p.flush()
+	if rec := parseLine(line); rec != nil {
+		p.pending = rec
+	} else {
+		p.pending = &record{raw: line}
+	}
+	return done
+}
+
+// flush 结束当前日志
+func (p *parser) flush() []*record {
+	rec := p.pending
+	p.pending = nil
+	if rec == nil {
+		return nil
+	}
+	if rec.info != nil && rec.raw != "" {
+		// 调用栈续行
+		rec.info.Stack = parseStack(rec.raw)
+		rec.raw = ""
+	}
+	return []*record{rec}
+}
+
+// parseLine 按格式解析一行日志，无法解析时返回 nil
+func parseLine(line string) *record {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "{"):
+		if rec, err := parseJSON(trimmed); err == nil {
+			return rec
+		}
+	case normalPrefix.MatchString(line):
+		return parseNormal(line)
+	case strings.Contains(line, "level="):
+		if rec, err := parseLogfmt(line); err == nil {
+			return rec
+		}
+	}
+	return nil
+}
+
+// parseJSON 解析 json 格式日志，兼容默认、ECS、GELF 和 GCP 结构
+func parseJSON(line string) (*record, error) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, errors.New("not a json object")
+	}
+
+	rec := &record{info: &gog.LogInfo{}}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value interface{}
+		if err = dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		info := rec.info
+		switch key {
+		case "tag", "log.logger", "_tag", "logger":
+			info.Tag = toString(value)
+		case "timestamp", "time", "@timestamp", "ts":
+			info.Time = parseTime(value)
+		case "level", "log.level", "severity":
+			rec.setLevel(value)
+		case "func", "caller":
+			str := toString(value)
+			if match := jsonCaller.FindStringSubmatch(str); match != nil {
+				info.File, info.Func = match[1], match[3]
+				info.Line, _ = strconv.Atoi(match[2])
+			} else if file, line, ok := splitCaller(str); ok {
+				info.File, info.Line = file, line
+			} else {
+				info.Func = str
+			}
+		case "file", "log.origin.file.name", "_file":
+			info.File = toString(value)
+		case "line", "log.origin.file.line", "_line":
+			info.Line, _ = strconv.Atoi(toString(value))
+		case "function", "log.origin.function", "_function":
+			info.Func = toString(value)
+		case "message", "msg", "short_message":
+			info.Body = toString(value)
+		case "stack", "error.stack_trace", "full_message":
+			info.Stack = toStack(value)
+		case "logging.googleapis.com/sourceLocation":
+			if source, ok := value.(map[string]interface{}); ok {
+				info.File = toString(source["file"])
+				info.Line, _ = strconv.Atoi(toString(source["line"]))
+				info.Func = toString(source["function"])
+			}
+		default:
+			info.Fields = append(info.Fields, toField(key, value))
+		}
+	}
+	if rec.levelName == "" {
+		rec.setLevel("INFO")
+	}
+	return rec, nil
+}
+
+// parseNormal 解析 normal 格式日志
+func parseNormal(line string) *record {
+	match := normalPrefix.FindStringSubmatchIndex(line)
+	rec := &record{info: &gog.LogInfo{}}
+	rec.info.Time = parseTime(line[match[2]:match[3]])
+	rec.setLevel(line[match[4]:match[5]])
+
+	rest := line[match[1]:]
+	if caller := normalCaller.FindStringSubmatch(rest); caller != nil {
+		rec.info.File = caller[1]
+		rec.info.Line, _ = strconv.Atoi(caller[2])
+		rec.info.ShortFile = !strings.HasPrefix(caller[1], "/")
+		rest = rest[len(caller[0])-1:]
+
+		// 函数名中可能包含括号，如 main.(*T).run
+		depth := 0
+		for i, c := range rest {
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				depth--
+				if depth == 0 {
+					rec.info.Func = rest[1:i]
+					rest = rest[i+1:]
+					break
+				}
+			}
+		}
+	}
+	if strings.HasPrefix(rest, "[") {
+		if end := strings.IndexByte(rest, ']'); end > 0 {
+			rec.info.Tag = rest[1:end]
+			rest = rest[end+1:]
+		}
+	}
+	rec.info.Body = rest
+	return rec
+}
+
+// parseLogfmt 解析 logfmt 格式日志
+func parseLogfmt(line string) (*record, error) {
+	rec := &record{info: &gog.LogInfo{}}
+	info := rec.info
+	for line = strings.TrimSpace(line); line != ""; line = strings.TrimLeft(line, " ") {
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 || strings.ContainsAny(line[:eq], " \"") {
+			return nil, errors.New("invalid logfmt pair")
+		}
+		key := line[:eq]
+		line = line[eq+1:]
+
+		var value string
+		quoted := strings.HasPrefix(line, `"`)
+		if quoted {
+			prefix, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, err
+			}
+			value, _ = strconv.Unquote(prefix)
+			line = line[len(prefix):]
+		} else if end := strings.IndexByte(line, ' '); end >= 0 {
+			value, line = line[:end], line[end:]
+		} else {
+			value, line = line, ""
+		}
+
+		switch key {
+		case "time", "ts":
+			info.Time = parseTime(value)
+		case "level":
+			rec.setLevel(value)
+		case "tag":
+			info.Tag = value
+		case "caller":
+			info.File, info.Line, _ = splitCaller(value)
+		case "func":
+			info.Func = value
+		case "msg", "message":
+			info.Body = value
+		case "stack":
+			info.Stack = parseStack(value)
+		default:
+			if quoted {
+				info.Fields = append(info.Fields, gog.String(key, value))
+			} else {
+				info.Fields = append(info.Fields, toField(key, json.Number(value)))
+			}
+		}
+	}
+	if rec.levelName == "" {
+		return nil, errors.New("missing level")
+	}
+	return rec, nil
+}
+
+// setLevel 设置日志级别，无法识别的级别保留名称并按 INFO 处理
+func (rec *record) setLevel(value interface{}) {
+	if num, ok := value.(json.Number); ok {
+		// GELF 使用 syslog 严重程度
+		n, _ := num.Int64()
+		switch {
+		case n >= 7:
+			rec.info.Level = gog.DEBUG
+		case n >= 5:
+			rec.info.Level = gog.INFO
+		case n == 4:
+			rec.info.Level = gog.WARN
+		case n == 3:
+			rec.info.Level = gog.ERROR
+		default:
+			rec.info.Level = gog.FATAL
+		}
+		rec.levelName = rec.info.Level.String()
+		return
+	}
+
+	name := toString(value)
+	if level, err := gog.ParseLevelStrict(name); err == nil && level.Valid() {
+		rec.info.Level, rec.levelName = level, level.String()
+		return
+	}
+	rec.info.Level, rec.levelName = gog.INFO, strings.ToUpper(name)
+}
+
+// splitCaller 拆分 "file:line"
+func splitCaller(caller string) (string, int, bool) {
+	idx := strings.LastIndexByte(caller, ':')
+	if idx < 0 {
+		return caller, 0, false
+	}
+	line, err := strconv.Atoi(caller[idx+1:])
+	if err != nil {
+		return caller, 0, false
+	}
+	return caller[:idx], line, true
+}
+
+// parseTime 解析时间，支持 gog 默认格式、RFC3339 以及秒或毫秒时间戳
+func parseTime(value interface{}) time.Time {
+	if num, ok := value.(json.Number); ok {
+		f, _ := num.Float64()
+		if f > 1e12 {
+			// 毫秒时间戳
+			return time.UnixMilli(int64(f))
+		}
+		sec := int64(f)
+		return time.Unix(sec, int64((f-float64(sec))*1e9))
+	}
+
+	str := toString(value)
+	if t, err := time.ParseInLocation(gog.DatePattern, str, time.Local); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339Nano, str); err == nil {
+		return t
+	}
+	if num := json.Number(str); strings.Trim(str, "0123456789.") == "" && str != "" {
+		return parseTime(num)
+	}
+	return time.Time{}
+}
+
+// parseStack 解析文本形式的调用栈，每个栈帧为函数名和 "file:line" 两行
+func parseStack(text string) []gog.StackFrame {
+	var (
+		stack []gog.StackFrame
+		frame gog.StackFrame
+	)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if file, num, ok := splitCaller(line); ok && frame.Func != "" {
+			frame.File, frame.Line = file, num
+			stack = append(stack, frame)
+			frame = gog.StackFrame{}
+			continue
+		}
+		frame.Func = line
+	}
+	return stack
+}
+
+// toStack 转换 json 中的调用栈，支持栈帧数组和文本
+func toStack(value interface{}) []gog.StackFrame {
+	if text, ok := value.(string); ok {
+		return parseStack(text)
+	}
+	data, _ := json.Marshal(value)
+	var stack []gog.StackFrame
+	_ = json.Unmarshal(data, &stack)
+	return stack
+}
+
+// toField 将 json 值转换为结构化字段，尽量保留原始类型
+func toField(key string, value interface{}) gog.Field {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return gog.Int64(key, n)
+		}
+		if f, err := v.Float64(); err == nil {
+			return gog.Float64(key, f)
+		}
+		if b, err := strconv.ParseBool(string(v)); err == nil {
+			return gog.Bool(key, b)
+		}
+		return gog.String(key, string(v))
+	case string:
+		return gog.String(key, v)
+	case bool:
+		return gog.Bool(key, v)
+	}
+	return gog.Any(key, value)
+}
+
+// toString 将 json 值转换为字符串
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return string(v)
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
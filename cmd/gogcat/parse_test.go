@@ -0,0 +1,62 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 16:20
+// version: 1.0.0
+// desc   : 日志行解析
+
+package main
+
+import (
+	"testing"
+
+	"github.com/yhyzgn/gog"
+)
+
+func TestParser(t *testing.T) {
+	p := &parser{}
+	var records []*record
+	for _, line := range []string{
+		`{"tag":"db","timestamp":"2026-10-19 15:36:59.344","level":"warning","func":"/a/db.go:42 (main.run)","message":"slow","rows":3}`,
+		"2026-10-19 15:36:59.344      ERROR    db.go:7   (main.(*T).run)[http]failed\n",
+		"\tmain.(*T).run\n",
+		"\t\t/a/db.go:7\n",
+		`time=2026-10-19T15:36:59Z level=notice caller=/a/db.go:9 msg="hello world" ok=true`,
+		"plain text",
+	} {
+		records = append(records, p.feed(line)...)
+	}
+	records = append(records, p.flush()...)
+	if len(records) != 4 {
+		t.Fatalf("records = %d, want 4", len(records))
+	}
+
+	if info := records[0].info; info.Level != gog.WARN || info.Tag != "db" || info.File != "/a/db.go" || info.Line != 42 ||
+		info.Func != "main.run" || info.Body != "slow" || len(info.Fields) != 1 || info.Fields[0].Type != gog.IntType {
+		t.Errorf("json record = %+v", info)
+	}
+	if info := records[1].info; info.Level != gog.ERROR || info.Func != "main.(*T).run" || info.Tag != "http" ||
+		info.Body != "failed" || len(info.Stack) != 1 || info.Stack[0].Line != 7 {
+		t.Errorf("normal record = %+v", info)
+	}
+	if rec := records[2]; rec.levelName != "NOTICE" || rec.info.Body != "hello world" || rec.info.Line != 9 ||
+		rec.info.Fields[0].Type != gog.BoolType {
+		t.Errorf("logfmt record = %+v %+v", rec, rec.info)
+	}
+	if rec := records[3]; rec.info != nil || rec.raw != "plain text" {
+		t.Errorf("raw record = %+v", rec)
+	}
+}
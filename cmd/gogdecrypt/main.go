@@ -0,0 +1,126 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 15:10
+// version: 1.0.0
+// desc   : gogdecrypt 加密日志解密工具
+//
+// 解密 EncryptWriter 写出的日志并输出到标准输出，轮换过的密钥需要全部提供：
+//
+//	gogdecrypt -key k1=old.key -key k2=new.key app.log.enc | gogcat
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yhyzgn/gog"
+)
+
+// keyFlags 可重复的 -key id=文件 参数
+type keyFlags map[string][]byte
+
+// String 实现 flag.Value
+func (kf keyFlags) String() string {
+	ids := make([]string, 0, len(kf))
+	for id := range kf {
+		ids = append(ids, id)
+	}
+	return strings.Join(ids, ",")
+}
+
+// Set 实现 flag.Value
+func (kf keyFlags) Set(value string) error {
+	idx := strings.IndexByte(value, '=')
+	if idx <= 0 {
+		return errors.New("key must be id=file")
+	}
+	key, err := readKey(value[idx+1:])
+	if err != nil {
+		return err
+	}
+	kf[value[:idx]] = key
+	return nil
+}
+
+func main() {
+	keys := make(keyFlags)
+	flag.Var(keys, "key", "密钥 id=密钥文件，可重复指定")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "用法: %s -key id=文件 [文件 ...]\n\n未指定文件时读取标准输入；密钥文件可以是原始字节、十六进制或 base64 文本\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if len(keys) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	failed := false
+	for _, path := range files {
+		file := os.Stdin
+		if path != "-" {
+			var err error
+			if file, err = os.Open(path); err != nil {
+				fmt.Fprintln(os.Stderr, "gogdecrypt:", err)
+				failed = true
+				continue
+			}
+		}
+		if err := gog.DecryptLog(bufio.NewReader(file), out, keys); err != nil {
+			fmt.Fprintf(os.Stderr, "gogdecrypt: %s: %v\n", path, err)
+			failed = true
+		}
+		if file != os.Stdin {
+			_ = file.Close()
+		}
+	}
+	if failed {
+		_ = out.Flush()
+		os.Exit(1)
+	}
+}
+
+// readKey 读取密钥文件，依次尝试十六进制、base64 和原始字节
+func readKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	text := string(bytes.TrimSpace(data))
+	if key, err := hex.DecodeString(text); err == nil && text != "" {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(text); err == nil && text != "" {
+		return key, nil
+	}
+	return data, nil
+}
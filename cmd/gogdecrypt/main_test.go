@@ -0,0 +1,95 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 15:10
+// version: 1.0.0
+// desc   : 日志解密命令测试
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yhyzgn/gog"
+)
+
+func TestReadKey(t *testing.T) {
+	key := bytes.Repeat([]byte{0xab}, 32)
+	dir := t.TempDir()
+	for name, content := range map[string][]byte{
+		"hex":    []byte(hex.EncodeToString(key) + "\n"),
+		"base64": []byte(base64.StdEncoding.EncodeToString(key) + "\n"),
+		"raw":    key,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := readKey(path)
+		if err != nil || !bytes.Equal(got, key) {
+			t.Fatalf("%s: key = %x, %v", name, got, err)
+		}
+	}
+	if _, err := readKey(filepath.Join(dir, "missing")); err == nil {
+		t.Fatal("missing key file should fail")
+	}
+}
+
+func TestKeyFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "k1.key")
+	key := bytes.Repeat([]byte{1}, 16)
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keys := make(keyFlags)
+	if err := keys.Set("k1=" + path); err != nil || !bytes.Equal(keys["k1"], key) {
+		t.Fatalf("set = %x, %v", keys["k1"], err)
+	}
+	if err := keys.Set(path); err == nil {
+		t.Fatal("key without id should fail")
+	}
+
+	// 日志使用 k2 加密，只提供了 k1
+	var data bytes.Buffer
+	ew, err := gog.NewEncryptWriter(&bufferWriter{&data}, "k2", bytes.Repeat([]byte{2}, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = ew.Write(&gog.LogInfo{}, []byte("hello\n"))
+	var out bytes.Buffer
+	if err = gog.DecryptLog(&data, &out, keys); err == nil || !strings.Contains(err.Error(), `"k2"`) {
+		t.Fatalf("decrypt = %v, want unknown key id k2", err)
+	}
+}
+
+// bufferWriter 写入内存的输出器
+type bufferWriter struct {
+	buf *bytes.Buffer
+}
+
+func (bw *bufferWriter) Write(_ *gog.LogInfo, data []byte) (int, error) {
+	return bw.buf.Write(data)
+}
+
+func (bw *bufferWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 13:40
+// version: 1.0.0
+// desc   : 颜色输出检测
+
+package gog
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/yhyzgn/gog/util"
+)
+
+// ColorWriter 能判断输出目标是否支持颜色的输出器
+//
+// 未实现该接口的输出器（文件、网络等）一律按不支持颜色处理
+type ColorWriter interface {
+	ColorEnabled(level Level) bool
+}
+
+// ColorFormatter 能按输出目标决定是否输出颜色的格式化
+type ColorFormatter interface {
+	FormatColor(level Level, levelName string, info *LogInfo, colorful bool) ([]byte, error)
+}
+
+// ColorEnabled 判断输出目标是否应该输出颜色
+//
+// 优先级：FORCE_COLOR > NO_COLOR > 是否为终端
+func ColorEnabled(w io.Writer) bool {
+	if envForceColor() {
+		return true
+	}
+	if envNoColor() {
+		return false
+	}
+	return util.IsTerminal(w)
+}
+
+// writerColorful 判断输出器在该级别下是否支持颜色
+func writerColorful(w Writer, level Level) bool {
+	if cw, ok := w.(ColorWriter); ok {
+		return cw.ColorEnabled(level)
+	}
+	return false
+}
+
+// envForceColor 解析 FORCE_COLOR 环境变量，空值、"0"、"false" 视为未设置
+func envForceColor() bool {
+	value, ok := os.LookupEnv("FORCE_COLOR")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(value) {
+	case "", "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// envNoColor 是否通过 NO_COLOR 环境变量禁用颜色，参考 https://no-color.org
+func envNoColor() bool {
+	return !envForceColor() && os.Getenv("NO_COLOR") != ""
+}
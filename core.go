@@ -27,34 +27,50 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-const (
-	queueSize = 1000
-)
-
 // Gog 日志处理器
 type Gog struct {
-	mu        sync.Mutex    // 同步锁
-	config    *Config       // 配置信息
-	callSkip  int           // 定位打印日志的文件、方法以及行号，需要跳过中间调用栈，直接定位到调用源头
-	level     Level         // 日志输出级别，只有 >= 该值的级别才会输出
-	shortFile bool          // 日志输出时，如果 shortFile=true 则只输出日志源的文件名，否则将输出完整路径
-	async     bool          // 是否启用异步
-	queue     chan *LogInfo // 异步队列
+	mu          sync.Mutex       // 同步锁
+	config      *Config          // 配置信息
+	callSkip    int              // 定位打印日志的文件、方法以及行号，需要跳过中间调用栈，直接定位到调用源头
+	wrapperSkip int              // callSkip 中包级函数封装的层数，派生的日志处理器被直接调用，不再跳过
+	level       Level            // 日志输出级别，只有 >= 该值的级别才会输出
+	shortFile   bool             // 日志输出时，如果 shortFile=true 则只输出日志源的文件名，否则将输出完整路径
+	callerMode  CallerMode       // 发生地文件的显示方式
+	funcMode    FuncMode         // 发生地函数的显示方式
+	stackLevel  Level            // 日志级别 >= 该值时记录调用栈，OFF 表示不记录
+	async       bool             // 是否启用异步
+	worker      *asyncWorker     // 异步输出协程，派生的日志处理器共用
+	redactor    *Redactor        // 敏感信息脱敏
+	hooks       []Hook           // 钩子
+	middlewares []Middleware     // 处理链中间件
+	chain       atomic.Value     // 由中间件和钩子组装好的处理链 Handler，修改时整体替换
+	metrics     *Metrics         // 指标统计
+	scope       *scopeBuffer     // 作用域缓冲，为 nil 时不缓冲
+	tagFilter   *tagFilterHolder // 标签过滤规则，派生的日志处理器共用
 }
 
 // NewGog 创建新的日志处理器
 func NewGog(level Level, callSkip int) *Gog {
 	gog := &Gog{
-		config:   defaultConfig,
-		callSkip: callSkip,
-		level:    level,
-		queue:    make(chan *LogInfo, queueSize),
+		config:     defaultConfig,
+		callSkip:   callSkip,
+		level:      level,
+		callerMode: CallerFull,
+		stackLevel: OFF,
+		worker:     newAsyncWorker(),
+		tagFilter:  &tagFilterHolder{},
+	}
+	gog.buildChain()
+	// 从环境变量读取标签过滤规则
+	if spec := os.Getenv(EnvTagFilter); spec != "" {
+		if err := gog.SetTagFilter(spec); err != nil {
+			log.Println(err)
+		}
 	}
-	// 开启异步输出
-	go gog.startAsyncOut()
 	return gog
 }
 
@@ -109,6 +125,41 @@ func (g *Gog) CallSkip(skip int) *Gog {
 	return g
 }
 
+// WithCallerSkip 派生一个多跳过 skip 层调用栈的日志处理器，不影响当前日志处理器
+//
+// 派生的日志处理器与当前日志处理器共用配置和异步队列；
+// 从默认日志对象派生时不包含包级函数封装的那一层，直接调用派生的日志处理器时 skip 为 0 即可
+func (g *Gog) WithCallerSkip(skip int) *Gog {
+	derived := g.clone()
+	derived.callSkip += skip
+	return derived
+}
+
+// clone 复制日志处理器
+func (g *Gog) clone() *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	derived := &Gog{
+		config:      g.config,
+		callSkip:    util.If(g.callSkip > g.wrapperSkip, g.callSkip-g.wrapperSkip, 0).(int),
+		level:       g.level,
+		shortFile:   g.shortFile,
+		callerMode:  g.callerMode,
+		funcMode:    g.funcMode,
+		stackLevel:  g.stackLevel,
+		async:       g.async,
+		worker:      g.worker,
+		redactor:    g.redactor,
+		hooks:       append([]Hook{}, g.hooks...),
+		middlewares: append([]Middleware{}, g.middlewares...),
+		metrics:     g.metrics,
+		scope:       g.scope,
+		tagFilter:   g.tagFilter,
+	}
+	derived.buildChain()
+	return derived
+}
+
 // Level 设置日志打印的最低优先级
 func (g *Gog) Level(level Level) *Gog {
 	g.mu.Lock()
@@ -119,22 +170,164 @@ func (g *Gog) Level(level Level) *Gog {
 
 // ShortFile 是否只显示文件名
 func (g *Gog) ShortFile(short bool) *Gog {
+	return g.CallerMode(util.If(short, CallerShort, CallerFull).(CallerMode))
+}
+
+// CallerMode 设置发生地文件的显示方式
+func (g *Gog) CallerMode(mode CallerMode) *Gog {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	g.shortFile = short
+	g.callerMode = mode
+	g.shortFile = mode != CallerFull
+	return g
+}
+
+// FuncMode 设置发生地函数的显示方式
+func (g *Gog) FuncMode(mode FuncMode) *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.funcMode = mode
+	return g
+}
+
+// StackLevel 设置记录调用栈的最低日志级别，OFF 表示不记录
+//
+// 默认不记录
+func (g *Gog) StackLevel(level Level) *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stackLevel = level
 	return g
 }
 
 // Async 是否启用异步
 //
-// 默认关闭
+// 默认关闭，开启时才启动异步输出协程，Close 时退出
 func (g *Gog) Async(async bool) *Gog {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 	g.async = async
+	if async {
+		g.worker.start()
+	}
+	return g
+}
+
+// Redactor 设置敏感信息脱敏处理器，为 nil 时不脱敏
+func (g *Gog) Redactor(redactor *Redactor) *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.redactor = redactor
+	return g
+}
+
+// TagFilter 设置标签过滤规则，为 nil 时不按标签过滤，可在运行时随时替换
+//
+// 与派生的日志处理器共用
+func (g *Gog) TagFilter(filter *TagFilter) *Gog {
+	g.tagFilter.store(filter)
 	return g
 }
 
+// SetTagFilter 解析并设置标签过滤规则，如 "*:INFO,db:DEBUG,noisy:OFF"，为空时不按标签过滤
+func (g *Gog) SetTagFilter(spec string) error {
+	if strings.TrimSpace(spec) == "" {
+		g.TagFilter(nil)
+		return nil
+	}
+	filter, err := ParseTagFilter(spec)
+	if err != nil {
+		return err
+	}
+	g.TagFilter(filter)
+	return nil
+}
+
+// Metrics 设置指标统计，为 nil 时不统计
+func (g *Gog) Metrics(metrics *Metrics) *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.metrics = metrics
+	return g
+}
+
+// AddHook 添加钩子
+func (g *Gog) AddHook(hook ...Hook) *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks = append(g.hooks, hook...)
+	g.buildChain()
+	return g
+}
+
+// Use 添加处理链中间件，按添加顺序执行
+func (g *Gog) Use(middleware ...Middleware) *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.middlewares = append(g.middlewares, middleware...)
+	g.buildChain()
+	return g
+}
+
+// ResetHooks 清除所有钩子和中间件
+func (g *Gog) ResetHooks() *Gog {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.hooks = nil
+	g.middlewares = nil
+	g.buildChain()
+	return g
+}
+
+// Sync 等待异步队列中的日志全部输出，再刷新并同步所有输出器
+//
+// 输出器实现了 Flusher 或 Syncer 时才会被刷新或同步，程序退出前应调用，FATAL 日志也会自动调用
+func (g *Gog) Sync() error {
+	g.worker.sync()
+
+	g.mu.Lock()
+	cfg := g.config
+	g.mu.Unlock()
+	if cfg == nil {
+		return nil
+	}
+
+	var result error
+	for _, w := range cfg.Writers {
+		if err := syncWriter(w); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// Close 同步并关闭所有输出器，并停止异步输出协程
+func (g *Gog) Close() error {
+	result := g.Sync()
+	g.worker.shutdown()
+
+	g.mu.Lock()
+	cfg := g.config
+	g.mu.Unlock()
+	if cfg == nil {
+		return result
+	}
+	for _, w := range cfg.Writers {
+		if err := w.Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// exit 同步所有输出器后结束进程
+func (g *Gog) exit() {
+	if err := g.Sync(); err != nil {
+		log.Println(err)
+	}
+	os.Exit(1)
+}
+
 // Trace 追踪打印
 func (g *Gog) Trace(body ...interface{}) {
 	g.Write("", TRACE, body...)
@@ -147,12 +340,12 @@ func (g *Gog) TraceTag(tag string, body ...interface{}) {
 
 // TraceF 追踪打印
 func (g *Gog) TraceF(format string, args ...interface{}) {
-	g.Write("", TRACE, resolveFormat(format, args...))
+	g.WriteF("", TRACE, format, args...)
 }
 
 // TraceTagF 追踪打印
 func (g *Gog) TraceTagF(tag string, format string, args ...interface{}) {
-	g.Write(tag, TRACE, resolveFormat(format, args...))
+	g.WriteF(tag, TRACE, format, args...)
 }
 
 // Debug 调试打印
@@ -167,12 +360,12 @@ func (g *Gog) DebugTag(tag string, body ...interface{}) {
 
 // DebugF 调试打印
 func (g *Gog) DebugF(format string, args ...interface{}) {
-	g.Write("", DEBUG, resolveFormat(format, args...))
+	g.WriteF("", DEBUG, format, args...)
 }
 
 // DebugTagF 调试打印
 func (g *Gog) DebugTagF(tag string, format string, args ...interface{}) {
-	g.Write(tag, DEBUG, resolveFormat(format, args...))
+	g.WriteF(tag, DEBUG, format, args...)
 }
 
 // Info 普通信息打印
@@ -187,12 +380,12 @@ func (g *Gog) InfoTag(tag string, body ...interface{}) {
 
 // InfoF 普通信息打印
 func (g *Gog) InfoF(format string, args ...interface{}) {
-	g.Write("", INFO, resolveFormat(format, args...))
+	g.WriteF("", INFO, format, args...)
 }
 
 // InfoTagF 普通信息打印
 func (g *Gog) InfoTagF(tag string, format string, args ...interface{}) {
-	g.Write(tag, INFO, resolveFormat(format, args...))
+	g.WriteF(tag, INFO, format, args...)
 }
 
 // Warn 警告打印
@@ -207,12 +400,12 @@ func (g *Gog) WarnTag(tag string, body ...interface{}) {
 
 // WarnF 警告打印
 func (g *Gog) WarnF(format string, args ...interface{}) {
-	g.Write("", WARN, resolveFormat(format, args...))
+	g.WriteF("", WARN, format, args...)
 }
 
 // WarnTagF 警告打印
 func (g *Gog) WarnTagF(tag string, format string, args ...interface{}) {
-	g.Write(tag, WARN, resolveFormat(format, args...))
+	g.WriteF(tag, WARN, format, args...)
 }
 
 // Error 错误打印
@@ -227,81 +420,210 @@ func (g *Gog) ErrorTag(tag string, body ...interface{}) {
 
 // ErrorF 错误打印
 func (g *Gog) ErrorF(format string, args ...interface{}) {
-	g.Write("", ERROR, resolveFormat(format, args...))
+	g.WriteF("", ERROR, format, args...)
 }
 
 // ErrorTagF 错误打印
 func (g *Gog) ErrorTagF(tag string, format string, args ...interface{}) {
-	g.Write(tag, ERROR, resolveFormat(format, args...))
+	g.WriteF(tag, ERROR, format, args...)
 }
 
 // Fatal 错误打印，并结束进程
 func (g *Gog) Fatal(body ...interface{}) {
-	defer func() {
-		time.Sleep(1 * time.Second)
-		os.Exit(1)
-	}()
+	defer g.exit()
 	g.Write("", FATAL, body...)
 }
 
 // FatalTag 错误打印，并结束进程
 func (g *Gog) FatalTag(tag string, body ...interface{}) {
-	defer func() {
-		time.Sleep(1 * time.Second)
-		os.Exit(1)
-	}()
+	defer g.exit()
 	g.Write(tag, FATAL, body...)
 }
 
 // FatalF 错误打印，并结束进程
 func (g *Gog) FatalF(format string, args ...interface{}) {
-	defer func() {
-		time.Sleep(1 * time.Second)
-		os.Exit(1)
-	}()
-	g.Write("", FATAL, resolveFormat(format, args...))
+	defer g.exit()
+	g.WriteF("", FATAL, format, args...)
 }
 
 // FatalTagF 错误打印，并结束进程
 func (g *Gog) FatalTagF(tag string, format string, args ...interface{}) {
-	defer func() {
-		time.Sleep(1 * time.Second)
-		os.Exit(1)
-	}()
-	g.Write(tag, FATAL, resolveFormat(format, args...))
+	defer g.exit()
+	g.WriteF(tag, FATAL, format, args...)
+}
+
+// Log 按指定级别打印，支持自定义日志级别，无效的级别按 INFO 打印
+func (g *Gog) Log(level Level, body ...interface{}) {
+	if level == FATAL {
+		defer g.exit()
+	}
+	if !level.Valid() {
+		level = INFO
+	}
+	g.Write("", level, body...)
+}
+
+// LogF 按指定级别格式化打印，支持自定义日志级别，无效的级别按 INFO 打印
+func (g *Gog) LogF(level Level, format string, args ...interface{}) {
+	if level == FATAL {
+		defer g.exit()
+	}
+	if !level.Valid() {
+		level = INFO
+	}
+	g.WriteF("", level, format, args...)
+}
+
+// Enabled 指定级别的日志是否会被输出
+//
+// 在作用域中时，会被缓冲的日志也视为会被输出
+func (g *Gog) Enabled(lvl Level) bool {
+	return g.EnabledTag("", lvl)
+}
+
+// EnabledTag 指定标签和级别的日志是否会被输出
+func (g *Gog) EnabledTag(tag string, lvl Level) bool {
+	if lvl == OFF {
+		return false
+	}
+	least := g.minLevel(tag)
+	return lvl.AtLeast(least) || least != OFF && g.scope != nil && g.scope.accepts(lvl)
+}
+
+// minLevel 标签的最低日志级别，匹配到标签过滤规则时使用规则的级别
+func (g *Gog) minLevel(tag string) Level {
+	if g.tagFilter != nil {
+		if filter := g.tagFilter.load(); filter != nil {
+			if level, ok := filter.Level(tag); ok {
+				return level
+			}
+		}
+	}
+	return g.level
+}
+
+// IsTraceEnabled TRACE 级别日志是否会被输出
+func (g *Gog) IsTraceEnabled() bool {
+	return g.Enabled(TRACE)
+}
+
+// IsDebugEnabled DEBUG 级别日志是否会被输出
+func (g *Gog) IsDebugEnabled() bool {
+	return g.Enabled(DEBUG)
+}
+
+// IsInfoEnabled INFO 级别日志是否会被输出
+func (g *Gog) IsInfoEnabled() bool {
+	return g.Enabled(INFO)
+}
+
+// IsWarnEnabled WARN 级别日志是否会被输出
+func (g *Gog) IsWarnEnabled() bool {
+	return g.Enabled(WARN)
+}
+
+// IsErrorEnabled ERROR 级别日志是否会被输出
+func (g *Gog) IsErrorEnabled() bool {
+	return g.Enabled(ERROR)
 }
 
 // Write 输出操作
 func (g *Gog) Write(tag string, lvl Level, body ...interface{}) {
-	if lvl == OFF || lvl < g.level || body == nil || len(body) == 0 {
+	g.write(tag, lvl, "", false, body)
+}
+
+// WriteF 格式化输出操作，只有日志真正输出时才会格式化
+func (g *Gog) WriteF(tag string, lvl Level, format string, args ...interface{}) {
+	g.write(tag, lvl, format, true, args)
+}
+
+// write 输出操作，Write 和 WriteF 的调用栈深度需保持一致
+func (g *Gog) write(tag string, lvl Level, format string, formatted bool, args []interface{}) {
+	if lvl == OFF || (!formatted && len(args) == 0) {
+		return
+	}
+	// 标签被过滤规则关闭时，作用域也不缓冲
+	least := g.minLevel(tag)
+	below := !lvl.AtLeast(least)
+	if below && (least == OFF || g.scope == nil || !g.scope.accepts(lvl)) {
 		return
 	}
 
-	bodyFormat := ""
-	for range body {
-		bodyFormat += "{}"
+	args = resolveLazy(args)
+	body, fields := splitFields(args)
+	if !formatted {
+		for range body {
+			format += "{}"
+		}
 	}
 	info := &LogInfo{
 		Tag:       tag,
 		Time:      time.Now(),
 		Level:     lvl,
-		Body:      resolveFormat(bodyFormat, body...),
+		Body:      resolveFormat(format, body...),
+		Fields:    fields,
 		ShortFile: g.shortFile,
 	}
 
-	// 需要跳过至少3层调用栈
-	file, funcName, line, ok := util.FileLineNumber(g.callSkip+3, g.shortFile)
-	if ok {
-		info.File = file
-		info.Func = funcName
-		info.Line = line
+	// 需要跳过至少4层调用栈，之后再跳过封装函数和注册的封装包
+	if frame, ok := util.Caller(g.callSkip+4, skipCallerFrame); ok {
+		info.File = callerFile(&frame, g.callerMode)
+		info.Func = callerFunc(&frame, g.funcMode)
+		info.Line = frame.Line
+	}
+	if g.stackLevel != OFF && lvl.AtLeast(g.stackLevel) {
+		info.Stack = captureStack(g.callSkip + 4)
+	}
+
+	if g.redactor != nil {
+		g.redactor.Redact(info)
+	}
+
+	// 格式化需要时记录协程 id，异步输出时已无法获取
+	if g.config != nil {
+		if gr, ok := g.config.Formatter.(goroutineRecorder); ok && gr.recordGoroutine() {
+			info.Goroutine = util.GoroutineID()
+		}
+	}
+
+	if g.scope != nil && g.scope.handle(g, info, below) {
+		return
+	}
+	g.dispatch(info)
+}
+
+// dispatch 交给处理链处理
+func (g *Gog) dispatch(info *LogInfo) {
+	g.chain.Load().(Handler)(info)
+}
+
+// buildChain 组装处理链，最后触发钩子并输出
+//
+// 处理链持有钩子的副本，之后修改钩子或中间件时重新组装，调用方需持有 g.mu
+func (g *Gog) buildChain() {
+	hooks := append([]Hook(nil), g.hooks...)
+	var chain Handler = func(info *LogInfo) {
+		g.emit(info, hooks)
+	}
+	for i := len(g.middlewares) - 1; i >= 0; i-- {
+		chain = g.middlewares[i](chain)
+	}
+	g.chain.Store(chain)
+}
+
+// emit 触发钩子并输出
+func (g *Gog) emit(info *LogInfo, hooks []Hook) {
+	for _, hook := range hooks {
+		if hookMatches(hook, info.Level) {
+			if err := hook.Fire(info); err != nil {
+				log.Println(err)
+			}
+		}
 	}
 
 	if g.async {
-		// 添加到异步队列
-		select {
-		case g.queue <- info:
-		default:
+		// 添加到异步队列，未启动或队列已满时同步输出
+		if !g.worker.enqueue(asyncLog{gog: g, info: info}) {
 			g.out(info)
 		}
 	} else {
@@ -319,15 +641,22 @@ func (g *Gog) out(info *LogInfo) {
 	if len(g.config.Writers) == 0 {
 		g.SetWriter(NewConsoleWriter())
 	}
+	if g.metrics != nil {
+		g.metrics.observeRecord(info)
+	}
 	for _, w := range g.config.Writers {
 		if g.config.Formatter != nil {
 			// 每个输出器自定义输出格式
-			data, err := g.config.Formatter.Format(info.Level, GetLevelName(info.Level), info)
+			data, err := formatFor(g.config.Formatter, w, info)
 			if err != nil {
 				log.Fatal(err)
 				return
 			}
-			if _, err := w.Write(info, data); err != nil {
+			n, err := w.Write(info, data)
+			if g.metrics != nil {
+				g.metrics.observeWrite(w, n, err)
+			}
+			if err != nil {
 				log.Println(err)
 			}
 			continue
@@ -336,10 +665,12 @@ func (g *Gog) out(info *LogInfo) {
 	}
 }
 
-func (g *Gog) startAsyncOut() {
-	for {
-		g.out(<-g.queue)
+// formatFor 按输出器格式化日志，支持颜色的格式化只在输出目标支持颜色时上色
+func formatFor(ftr Formatter, w Writer, info *LogInfo) ([]byte, error) {
+	if cf, ok := ftr.(ColorFormatter); ok {
+		return cf.FormatColor(info.Level, GetLevelName(info.Level), info, writerColorful(w, info.Level))
 	}
+	return ftr.Format(info.Level, GetLevelName(info.Level), info)
 }
 
 func resolveFormat(format string, args ...interface{}) string {
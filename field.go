@@ -0,0 +1,292 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-20 9:36
+// version: 1.0.0
+// desc   : 结构化字段
+
+package gog
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// FieldType 字段类型
+type FieldType uint8
+
+// 字段类型，决定了值保存在 Field 的哪个成员中
+const (
+	UnknownType  FieldType = iota // 未知类型
+	StringType                    // 字符串，保存在 String
+	IntType                       // 有符号整数，保存在 Integer
+	UintType                      // 无符号整数，按位保存在 Integer
+	FloatType                     // 浮点数，按 IEEE 754 位保存在 Integer
+	BoolType                      // 布尔值，Integer 为 1 表示 true
+	DurationType                  // 时长，纳秒数保存在 Integer
+	TimeType                      // 时间，纳秒时间戳保存在 Integer，时区保存在 Interface；超出纳秒时间戳范围时整体保存在 Interface
+	ErrorType                     // 错误，保存在 Interface
+	StringerType                  // fmt.Stringer，保存在 Interface
+	AnyType                       // 任意值，保存在 Interface
+)
+
+// Field 结构化字段
+//
+// 常见类型的值直接保存在 Integer 或 String 中，避免装箱
+type Field struct {
+	Key       string
+	Type      FieldType
+	Integer   int64
+	String    string
+	Interface interface{}
+}
+
+// String 字符串字段
+func String(key, value string) Field {
+	return Field{Key: key, Type: StringType, String: value}
+}
+
+// Int 整数字段
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int32 整数字段
+func Int32(key string, value int32) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 整数字段
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: IntType, Integer: value}
+}
+
+// Uint 无符号整数字段
+func Uint(key string, value uint) Field {
+	return Uint64(key, uint64(value))
+}
+
+// Uint64 无符号整数字段
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Type: UintType, Integer: int64(value)}
+}
+
+// Float64 浮点数字段
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: FloatType, Integer: int64(math.Float64bits(value))}
+}
+
+// Bool 布尔字段
+func Bool(key string, value bool) Field {
+	var integer int64
+	if value {
+		integer = 1
+	}
+	return Field{Key: key, Type: BoolType, Integer: integer}
+}
+
+// Duration 时长字段
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Integer: int64(value)}
+}
+
+// 纳秒时间戳能表示的时间范围，约为 1678 年到 2262 年
+var (
+	minNanoTime = time.Unix(0, math.MinInt64)
+	maxNanoTime = time.Unix(0, math.MaxInt64)
+)
+
+// Time 时间字段
+func Time(key string, value time.Time) Field {
+	if value.Before(minNanoTime) || value.After(maxNanoTime) {
+		// 零值或超出范围的时间无法用纳秒时间戳表示
+		return Field{Key: key, Type: TimeType, Interface: value}
+	}
+	return Field{Key: key, Type: TimeType, Integer: value.UnixNano(), Interface: value.Location()}
+}
+
+// Err 错误字段，键名为 "error"
+func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// NamedErr 指定键名的错误字段
+func NamedErr(key string, err error) Field {
+	if err == nil {
+		return Field{Key: key, Type: AnyType}
+	}
+	return Field{Key: key, Type: ErrorType, Interface: err}
+}
+
+// Stringer fmt.Stringer 字段
+func Stringer(key string, value fmt.Stringer) Field {
+	return Field{Key: key, Type: StringerType, Interface: value}
+}
+
+// Any 任意值字段，常见类型会转换为对应的类型字段
+func Any(key string, value interface{}) Field {
+	switch v := value.(type) {
+	case Field:
+		v.Key = key
+		return v
+	case string:
+		return String(key, v)
+	case bool:
+		return Bool(key, v)
+	case int:
+		return Int(key, v)
+	case int8:
+		return Int64(key, int64(v))
+	case int16:
+		return Int64(key, int64(v))
+	case int32:
+		return Int32(key, v)
+	case int64:
+		return Int64(key, v)
+	case uint:
+		return Uint(key, v)
+	case uint8:
+		return Uint64(key, uint64(v))
+	case uint16:
+		return Uint64(key, uint64(v))
+	case uint32:
+		return Uint64(key, uint64(v))
+	case uint64:
+		return Uint64(key, v)
+	case float32:
+		return Float64(key, float64(v))
+	case float64:
+		return Float64(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	case error:
+		return NamedErr(key, v)
+	case fmt.Stringer:
+		return Stringer(key, v)
+	}
+	return Field{Key: key, Type: AnyType, Interface: value}
+}
+
+// Value 获取字段的原始值
+func (f Field) Value() interface{} {
+	switch f.Type {
+	case StringType:
+		return f.String
+	case IntType:
+		return f.Integer
+	case UintType:
+		return uint64(f.Integer)
+	case FloatType:
+		return math.Float64frombits(uint64(f.Integer))
+	case BoolType:
+		return f.Integer == 1
+	case DurationType:
+		return time.Duration(f.Integer)
+	case TimeType:
+		return f.time()
+	}
+	return f.Interface
+}
+
+// ValueString 获取字段值的文本形式
+func (f Field) ValueString() (str string) {
+	switch f.Type {
+	case StringType:
+		return f.String
+	case IntType:
+		return strconv.FormatInt(f.Integer, 10)
+	case UintType:
+		return strconv.FormatUint(uint64(f.Integer), 10)
+	case FloatType:
+		return strconv.FormatFloat(math.Float64frombits(uint64(f.Integer)), 'g', -1, 64)
+	case BoolType:
+		return strconv.FormatBool(f.Integer == 1)
+	case DurationType:
+		return time.Duration(f.Integer).String()
+	case TimeType:
+		return f.time().Format(time.RFC3339Nano)
+	case ErrorType:
+		return f.Interface.(error).Error()
+	case StringerType:
+		// 值为 nil 指针时 String() 可能会 panic
+		defer func() {
+			if err := recover(); err != nil {
+				str = "<nil>"
+			}
+		}()
+		return f.Interface.(fmt.Stringer).String()
+	}
+	if f.Interface == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%+v", f.Interface)
+}
+
+// time 还原时间字段
+func (f Field) time() time.Time {
+	if t, ok := f.Interface.(time.Time); ok {
+		return t
+	}
+	t := time.Unix(0, f.Integer)
+	if loc, ok := f.Interface.(*time.Location); ok && loc != nil {
+		t = t.In(loc)
+	}
+	return t
+}
+
+// appendJSON 以 json 原生类型写入字段值
+func (f Field) appendJSON(enc *jsonEncoder) error {
+	switch f.Type {
+	case IntType:
+		enc.appendInt(f.Integer)
+	case UintType:
+		enc.appendUint(uint64(f.Integer))
+	case FloatType:
+		enc.appendFloat(math.Float64frombits(uint64(f.Integer)), 64)
+	case BoolType:
+		enc.appendBool(f.Integer == 1)
+	case AnyType:
+		return enc.appendInterface(f.Interface)
+	default:
+		enc.appendString(f.ValueString())
+	}
+	return nil
+}
+
+// splitFields 将参数中的 Field 分离出来
+func splitFields(args []interface{}) ([]interface{}, []Field) {
+	var fields []Field
+	values := args
+	for i, arg := range args {
+		field, ok := arg.(Field)
+		if !ok {
+			if fields != nil {
+				values = append(values, arg)
+			}
+			continue
+		}
+		if fields == nil {
+			// 首次遇到字段时才拷贝，没有字段时不产生额外分配
+			values = append(make([]interface{}, 0, len(args)), args[:i]...)
+		}
+		fields = append(fields, field)
+	}
+	return values, fields
+}
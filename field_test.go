@@ -0,0 +1,139 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-20 9:36
+// version: 1.0.0
+// desc   : 结构化字段测试
+
+package gog
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type fieldStringer struct {
+	name string
+}
+
+func (fs *fieldStringer) String() string {
+	return "stringer:" + fs.name
+}
+
+func TestSplitFields(t *testing.T) {
+	args := []interface{}{"a", 1, true}
+	values, fields := splitFields(args)
+	if fields != nil || len(values) != 3 || &values[0] != &args[0] {
+		t.Fatalf("args without fields should be returned as is: %v %v", values, fields)
+	}
+
+	args = []interface{}{"a", Int("n", 1), "b", String("s", "x")}
+	values, fields = splitFields(args)
+	if len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Fatalf("values = %v", values)
+	}
+	if len(fields) != 2 || fields[0].Key != "n" || fields[1].Key != "s" {
+		t.Fatalf("fields = %v", fields)
+	}
+	// 原参数不被修改
+	if args[1].(Field).Key != "n" || args[2] != "b" {
+		t.Fatalf("args modified: %v", args)
+	}
+}
+
+func TestFieldEncoding(t *testing.T) {
+	at := time.Date(2026, 10, 13, 10, 10, 0, 5, time.FixedZone("CST", 8*3600))
+	var nilStringer *fieldStringer
+	cases := []struct {
+		field Field
+		json  string
+		text  string
+	}{
+		{String("k", "v \"q\""), `"v \"q\""`, `v "q"`},
+		{Int("k", -7), `-7`, `-7`},
+		{Int32("k", math.MinInt32), `-2147483648`, `-2147483648`},
+		{Int64("k", math.MaxInt64), `9223372036854775807`, `9223372036854775807`},
+		{Uint("k", 7), `7`, `7`},
+		{Uint64("k", math.MaxUint64), `18446744073709551615`, `18446744073709551615`},
+		{Float64("k", 0.5), `0.5`, `0.5`},
+		{Float64("k", 1e-7), `1e-7`, `1e-07`},
+		{Bool("k", true), `true`, `true`},
+		{Bool("k", false), `false`, `false`},
+		{Duration("k", 1500*time.Millisecond), `"1.5s"`, `1.5s`},
+		{Time("k", at), `"2026-10-13T10:10:00.000000005+08:00"`, `2026-10-13T10:10:00.000000005+08:00`},
+		{Time("k", time.Time{}), `"0001-01-01T00:00:00Z"`, `0001-01-01T00:00:00Z`},
+		{Time("k", time.Date(3000, 1, 2, 3, 4, 5, 6, time.UTC)), `"3000-01-02T03:04:05.000000006Z"`, `3000-01-02T03:04:05.000000006Z`},
+		{Err(errors.New("boom")), `"boom"`, `boom`},
+		{NamedErr("k", nil), `null`, `<nil>`},
+		{Stringer("k", &fieldStringer{name: "x"}), `"stringer:x"`, `stringer:x`},
+		{Stringer("k", nilStringer), `"\u003cnil\u003e"`, `<nil>`},
+		{Any("k", []string{"a", "b"}), `["a","b"]`, `[a b]`},
+		{Any("k", map[string]int{"n": 1}), `{"n":1}`, `map[n:1]`},
+		{Any("k", uint8(8)), `8`, `8`},
+		{Any("k", float32(0.25)), `0.25`, `0.25`},
+		{Any("k", 3*time.Second), `"3s"`, `3s`},
+		{Any("k", Int("other", 1)), `1`, `1`},
+	}
+
+	for _, c := range cases {
+		out := &bytesWriter{}
+		g := NewGog(INFO, 0).SetConfig(&Config{
+			Formatter: NewJSONSchemaFormatter(JSONSchema{MessageKey: "msg", FieldsKey: "fields"}, ""),
+			Writers:   []Writer{out},
+		})
+		g.Info("body", c.field)
+		key := c.field.Key
+		if want := `{"msg":"body","fields":{"` + key + `":` + c.json + "}}\n"; out.String() != want {
+			t.Errorf("%s json: got %s, want %s", key, out.String(), want)
+		}
+
+		out.Reset()
+		lf := NewLogfmtFormatter()
+		lf.Keys = LogfmtKeys{Message: "msg"}
+		g.SetConfig(&Config{Formatter: lf, Writers: []Writer{out}})
+		g.Info("body", c.field)
+		if want := "msg=body " + string(appendLogfmtPair(nil, key, c.text)) + "\n"; out.String() != want {
+			t.Errorf("%s logfmt: got %q, want %q", key, out.String(), want)
+		}
+		if got := c.field.ValueString(); got != c.text {
+			t.Errorf("%s text: got %q, want %q", key, got, c.text)
+		}
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	at := time.Date(2026, 10, 13, 10, 10, 0, 0, time.UTC)
+	cases := []struct {
+		field Field
+		want  interface{}
+	}{
+		{String("k", "v"), "v"},
+		{Int("k", -1), int64(-1)},
+		{Uint64("k", math.MaxUint64), uint64(math.MaxUint64)},
+		{Float64("k", 2.5), 2.5},
+		{Bool("k", true), true},
+		{Duration("k", time.Second), time.Second},
+		{Time("k", at), at},
+		{Time("k", time.Time{}), time.Time{}},
+	}
+	for _, c := range cases {
+		if got := c.field.Value(); got != c.want {
+			t.Errorf("%v: got %v, want %v", c.field.Type, got, c.want)
+		}
+	}
+}
@@ -64,6 +64,11 @@ func Colorful(lvl Level) *golus.Stylus {
 	case FATAL:
 		stylus.FontColor(golus.FontRed).FontStyle(golus.StyleBold)
 		break
+	default:
+		// 自定义日志级别
+		if spec := customLevel(lvl); spec != nil && spec.Color != nil {
+			return spec.Color
+		}
 	}
 	return stylus
 }
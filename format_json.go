@@ -21,31 +21,153 @@
 package gog
 
 import (
+	"bytes"
 	"encoding/json"
+	"os"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	// TimeLayoutEpochMillis 时间输出为毫秒时间戳
+	TimeLayoutEpochMillis = "epoch_millis"
+	// TimeLayoutEpochSeconds 时间输出为秒时间戳（带毫秒小数）
+	TimeLayoutEpochSeconds = "epoch_seconds"
+)
+
+var (
+	pid         = os.Getpid()   // 当前进程 id
+	hostname, _ = os.Hostname() // 当前主机名
 )
 
 // JSONFormatter json 格式化
 type JSONFormatter struct {
-	Pretty     bool   // 是否美化 json 数据
-	TimeLayout string // 日期时间格式
+	Pretty     bool       // 是否美化 json 数据
+	TimeLayout string     // 日期时间格式，也可以是 TimeLayoutEpochMillis、TimeLayoutEpochSeconds
+	Schema     JSONSchema // json 日志结构
+}
+
+// JSONSchema json 日志结构定义，键名为空时不输出该字段
+type JSONSchema struct {
+	TagKey         string      // 标签
+	TimeKey        string      // 时间
+	LevelKey       string      // 日志级别
+	CallerKey      string      // 发生地，格式为 "file:line (func)"
+	SourceKey      string      // 不为空时，FileKey、LineKey、FuncKey 嵌套在该键对应的对象中
+	FileKey        string      // 发生地文件
+	LineKey        string      // 发生地行号
+	FuncKey        string      // 发生地函数
+	MessageKey     string      // 日志详情
+	FieldsKey      string      // 不为空时，结构化字段嵌套在该键对应的对象中
+	FieldPrefix    string      // 结构化字段键名前缀
+	ConflictPrefix string      // 未嵌套的结构化字段与固定键名冲突时，在 FieldPrefix 之后追加的前缀，为空时使用 "fields."
+	PIDKey         string      // 进程 id
+	HostnameKey    string      // 主机名
+	GoroutineKey   string      // 协程 id
+	StackKey       string      // 调用栈
+	StackString    bool        // 调用栈以文本形式输出，否则输出为栈帧数组
+	Static         []JSONField // 每条日志都附带的固定字段
+	OmitEmpty      bool        // 标签、文件、函数为空时不输出
+
+	// LevelValue 日志级别的输出值，为 nil 时输出级别名称
+	LevelValue func(level Level, levelName string) interface{}
+}
+
+// JSONField json 字段
+type JSONField struct {
+	Key   string
+	Value interface{}
 }
 
-// json 日志的一些字段
-type jsonLog struct {
-	Tag       string      `json:"tag"`
-	Timestamp string      `json:"timestamp"`
-	Level     string      `json:"level"`
-	Func      string      `json:"func"`
-	Message   interface{} `json:"message"`
+// DefaultJSONSchema 默认的 json 日志结构
+func DefaultJSONSchema() JSONSchema {
+	return JSONSchema{
+		TagKey:     "tag",
+		TimeKey:    "timestamp",
+		LevelKey:   "level",
+		CallerKey:  "func",
+		MessageKey: "message",
+		StackKey:   "stack",
+	}
 }
 
+// ECSJSONSchema Elastic Common Schema 日志结构
+func ECSJSONSchema() JSONSchema {
+	return JSONSchema{
+		TagKey:       "log.logger",
+		TimeKey:      "@timestamp",
+		LevelKey:     "log.level",
+		FileKey:      "log.origin.file.name",
+		LineKey:      "log.origin.file.line",
+		FuncKey:      "log.origin.function",
+		MessageKey:   "message",
+		PIDKey:       "process.pid",
+		HostnameKey:  "host.hostname",
+		GoroutineKey: "process.thread.id",
+		StackKey:     "error.stack_trace",
+		StackString:  true,
+		Static:       []JSONField{{Key: "ecs.version", Value: "1.6.0"}},
+		OmitEmpty:    true,
+		LevelValue: func(level Level, levelName string) interface{} {
+			return strings.ToLower(levelName)
+		},
+	}
+}
+
+// GELFJSONSchema Graylog GELF 1.1 日志结构
+func GELFJSONSchema() JSONSchema {
+	return JSONSchema{
+		TagKey:       "_tag",
+		TimeKey:      "timestamp",
+		LevelKey:     "level",
+		FileKey:      "_file",
+		LineKey:      "_line",
+		FuncKey:      "_function",
+		MessageKey:   "short_message",
+		PIDKey:       "_pid",
+		HostnameKey:  "host",
+		GoroutineKey: "_goroutine",
+		StackKey:     "full_message",
+		StackString:  true,
+		FieldPrefix:  "_",
+		Static:       []JSONField{{Key: "version", Value: "1.1"}},
+		OmitEmpty:    true,
+		LevelValue: func(level Level, levelName string) interface{} {
+			return SyslogSeverity(level)
+		},
+	}
+}
+
+// GCPJSONSchema Google Cloud Logging 结构化日志结构
+func GCPJSONSchema() JSONSchema {
+	return JSONSchema{
+		TagKey:      "tag",
+		TimeKey:     "time",
+		LevelKey:    "severity",
+		SourceKey:   "logging.googleapis.com/sourceLocation",
+		FileKey:     "file",
+		LineKey:     "line",
+		FuncKey:     "function",
+		MessageKey:  "message",
+		StackKey:    "stack_trace",
+		StackString: true,
+		OmitEmpty:   true,
+		LevelValue: func(level Level, levelName string) interface{} {
+			return gcpSeverities[SyslogSeverity(level)]
+		},
+	}
+}
+
+// Google Cloud Logging 的日志级别，按 syslog 严重程度排列
+var gcpSeverities = []string{"EMERGENCY", "ALERT", "CRITICAL", "ERROR", "WARNING", "NOTICE", "INFO", "DEBUG"}
+
 // newJSONFormatter 创建 json 格式化对象
 func newJSONFormatter(pretty bool) *JSONFormatter {
 	return &JSONFormatter{
 		Pretty:     pretty,
 		TimeLayout: DatePattern,
+		Schema:     DefaultJSONSchema(),
 	}
 }
 
@@ -59,30 +181,212 @@ func NewJSONPrettyFormatter() *JSONFormatter {
 	return newJSONFormatter(true)
 }
 
+// NewJSONSchemaFormatter 创建指定日志结构的 json 格式化对象
+func NewJSONSchemaFormatter(schema JSONSchema, timeLayout string) *JSONFormatter {
+	return &JSONFormatter{
+		TimeLayout: timeLayout,
+		Schema:     schema,
+	}
+}
+
+// NewECSFormatter 创建 Elastic Common Schema 格式化对象
+func NewECSFormatter() *JSONFormatter {
+	return NewJSONSchemaFormatter(ECSJSONSchema(), time.RFC3339Nano)
+}
+
+// NewGELFFormatter 创建 Graylog GELF 格式化对象
+func NewGELFFormatter() *JSONFormatter {
+	return NewJSONSchemaFormatter(GELFJSONSchema(), TimeLayoutEpochSeconds)
+}
+
+// NewGCPFormatter 创建 Google Cloud Logging 格式化对象
+func NewGCPFormatter() *JSONFormatter {
+	return NewJSONSchemaFormatter(GCPJSONSchema(), time.RFC3339Nano)
+}
+
 // Format 具体的格式化定义
 func (jf *JSONFormatter) Format(level Level, levelName string, info *LogInfo) ([]byte, error) {
-	log := jsonLog{
-		Tag:       info.Tag,
-		Timestamp: info.Time.Format(DatePattern),
-		Level:     levelName,
-		Func:      info.File + ":" + strconv.Itoa(info.Line) + " (" + info.Func + ")",
-		Message:   info.Body,
-	}
-
-	var (
-		bs  []byte
-		err error
-	)
-	if jf.Pretty {
-		bs, err = json.MarshalIndent(log, "", "\t")
+	schema := &jf.Schema
+	enc := getJSONEncoder()
+	defer putJSONEncoder(enc)
+
+	enc.openObject()
+	if schema.TagKey != "" && (info.Tag != "" || !schema.OmitEmpty) {
+		enc.key(schema.TagKey)
+		enc.appendString(info.Tag)
+	}
+	if schema.TimeKey != "" {
+		enc.key(schema.TimeKey)
+		jf.appendTime(enc, info.Time)
+	}
+	if schema.LevelKey != "" {
+		enc.key(schema.LevelKey)
+		if schema.LevelValue != nil {
+			if err := enc.appendInterface(schema.LevelValue(level, levelName)); err != nil {
+				return nil, err
+			}
+		} else {
+			enc.appendString(levelName)
+		}
+	}
+	if schema.CallerKey != "" {
+		enc.key(schema.CallerKey)
+		enc.appendString(info.File + ":" + strconv.Itoa(info.Line) + " (" + info.Func + ")")
+	}
+	if schema.SourceKey != "" {
+		enc.key(schema.SourceKey)
+		enc.openObject()
+		jf.appendSource(enc, info)
+		enc.closeObject()
 	} else {
-		bs, err = json.Marshal(log)
+		jf.appendSource(enc, info)
+	}
+	if schema.MessageKey != "" {
+		enc.key(schema.MessageKey)
+		enc.appendString(info.Body)
+	}
+	if len(info.Fields) > 0 {
+		if err := jf.appendFields(enc, info.Fields); err != nil {
+			return nil, err
+		}
+	}
+	if schema.PIDKey != "" {
+		enc.key(schema.PIDKey)
+		enc.appendInt(int64(pid))
+	}
+	if schema.HostnameKey != "" {
+		enc.key(schema.HostnameKey)
+		enc.appendString(hostname)
+	}
+	if schema.GoroutineKey != "" {
+		enc.key(schema.GoroutineKey)
+		enc.appendUint(info.Goroutine)
+	}
+	if schema.StackKey != "" && len(info.Stack) > 0 {
+		enc.key(schema.StackKey)
+		jf.appendStack(enc, info.Stack)
+	}
+	for _, field := range schema.Static {
+		enc.key(field.Key)
+		if err := enc.appendInterface(field.Value); err != nil {
+			return nil, err
+		}
+	}
+	enc.closeObject()
+
+	if jf.Pretty {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, enc.buf, "", "\t"); err != nil {
+			return nil, err
+		}
+		pretty.WriteByte('\n')
+		return pretty.Bytes(), nil
+	}
+	// 缓冲区会被复用，需要拷贝后返回
+	data := make([]byte, len(enc.buf)+1)
+	copy(data, enc.buf)
+	data[len(enc.buf)] = '\n'
+	return data, nil
+}
+
+// appendFields 写入结构化字段
+func (jf *JSONFormatter) appendFields(enc *jsonEncoder, fields []Field) error {
+	schema := &jf.Schema
+	if schema.FieldsKey != "" {
+		enc.key(schema.FieldsKey)
+		enc.openObject()
+		defer enc.closeObject()
+	}
+	for _, field := range fields {
+		key := schema.FieldPrefix + field.Key
+		if schema.FieldsKey == "" && schema.reserved(key) {
+			prefix := schema.ConflictPrefix
+			if prefix == "" {
+				prefix = "fields."
+			}
+			key = schema.FieldPrefix + prefix + field.Key
+		}
+		enc.key(key)
+		if err := field.appendJSON(enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserved 键名是否与日志结构中位于顶层的固定键名相同
+func (schema *JSONSchema) reserved(key string) bool {
+	if key == "" {
+		return false
+	}
+	switch key {
+	case schema.TagKey, schema.TimeKey, schema.LevelKey, schema.CallerKey, schema.SourceKey, schema.MessageKey,
+		schema.PIDKey, schema.HostnameKey, schema.GoroutineKey, schema.StackKey:
+		return true
+	}
+	if schema.SourceKey == "" && (key == schema.FileKey || key == schema.LineKey || key == schema.FuncKey) {
+		return true
 	}
-	if err != nil {
-		return nil, err
+	for _, field := range schema.Static {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// appendStack 写入调用栈
+func (jf *JSONFormatter) appendStack(enc *jsonEncoder, stack []StackFrame) {
+	if jf.Schema.StackString {
+		enc.appendString(stackString(stack, ""))
+		return
+	}
+	enc.openArray()
+	for _, frame := range stack {
+		enc.openObject()
+		enc.key("func")
+		enc.appendString(frame.Func)
+		enc.key("file")
+		enc.appendString(frame.File)
+		enc.key("line")
+		enc.appendInt(int64(frame.Line))
+		enc.closeObject()
+	}
+	enc.closeArray()
+}
+
+// appendSource 写入发生地文件、行号和函数
+func (jf *JSONFormatter) appendSource(enc *jsonEncoder, info *LogInfo) {
+	schema := &jf.Schema
+	if schema.FileKey != "" && (info.File != "" || !schema.OmitEmpty) {
+		enc.key(schema.FileKey)
+		enc.appendString(info.File)
+	}
+	if schema.LineKey != "" {
+		enc.key(schema.LineKey)
+		enc.appendInt(int64(info.Line))
+	}
+	if schema.FuncKey != "" && (info.Func != "" || !schema.OmitEmpty) {
+		enc.key(schema.FuncKey)
+		enc.appendString(info.Func)
+	}
+}
+
+// recordGoroutine 是否需要记录协程 id
+func (jf *JSONFormatter) recordGoroutine() bool {
+	return jf.Schema.GoroutineKey != ""
+}
+
+// appendTime 根据 TimeLayout 写入时间
+func (jf *JSONFormatter) appendTime(enc *jsonEncoder, t time.Time) {
+	switch jf.TimeLayout {
+	case TimeLayoutEpochMillis:
+		enc.appendInt(t.UnixNano() / int64(time.Millisecond))
+	case TimeLayoutEpochSeconds:
+		enc.appendFloat(float64(t.UnixNano()/int64(time.Millisecond))/1e3, 64)
+	case "":
+		enc.appendString(t.Format(DatePattern))
+	default:
+		enc.appendString(t.Format(jf.TimeLayout))
 	}
-	var sb strings.Builder
-	sb.Write(bs)
-	sb.WriteString("\n")
-	return []byte(sb.String()), nil
 }
@@ -0,0 +1,330 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 16:47
+// version: 1.0.0
+// desc   : JSON格式化
+
+package gog
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 基于 encoding/json 的旧版实现，作为对照
+type reflectJSONLog struct {
+	Tag       string      `json:"tag"`
+	Timestamp string      `json:"timestamp"`
+	Level     string      `json:"level"`
+	Func      string      `json:"func"`
+	Message   interface{} `json:"message"`
+}
+
+func reflectJSONFormat(levelName string, info *LogInfo) ([]byte, error) {
+	bs, err := json.Marshal(reflectJSONLog{
+		Tag:       info.Tag,
+		Timestamp: info.Time.Format(DatePattern),
+		Level:     levelName,
+		Func:      info.File + ":" + strconv.Itoa(info.Line) + " (" + info.Func + ")",
+		Message:   info.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	sb.Write(bs)
+	sb.WriteString("\n")
+	return []byte(sb.String()), nil
+}
+
+func newBenchLogInfo(body string) *LogInfo {
+	return &LogInfo{
+		Tag:   "http.access",
+		Time:  time.Date(2026, 10, 19, 16, 47, 0, 123000000, time.UTC),
+		Level: INFO,
+		Body:  body,
+		File:  "handler.go",
+		Func:  "ServeHTTP",
+		Line:  128,
+	}
+}
+
+func TestJSONFormatterCompatible(t *testing.T) {
+	bodies := []string{
+		"plain message",
+		"quote \" backslash \\ slash /",
+		"control \n\r\t\x00\x1f chars",
+		"html <script>&</script>",
+		"unicode 中文 \u2028 \u2029 emoji 😀",
+		"invalid \xff\xfe utf8",
+	}
+	ftr := NewJSONFormatter()
+	for _, body := range bodies {
+		info := newBenchLogInfo(body)
+		want, _ := reflectJSONFormat("INFO", info)
+		got, err := ftr.Format(INFO, "INFO", info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != string(want) {
+			t.Errorf("body %q:\n got %s\nwant %s", body, got, want)
+		}
+	}
+}
+
+func TestJSONEncoderCompatible(t *testing.T) {
+	values := []interface{}{
+		nil, "", "text", true, false,
+		0, -1, int8(-8), int16(16), int32(-32), int64(math.MinInt64),
+		uint(1), uint8(8), uint16(16), uint32(32), uint64(math.MaxUint64),
+		0.0, 1.5, -2.25, 1e20, 1e21, 123456789e30, 1e-6, 1e-7, 1.5e-7, -3e-10, 1e-100, 1e100, math.MaxFloat64, math.SmallestNonzeroFloat64,
+		float32(0.1), float32(1e-7), float32(3e-10), float32(1e21), float32(math.MaxFloat32),
+		json.Number("42"), json.Number("-1.5e3"), json.Number(""),
+		[]int{1, 2}, map[string]string{"k": "v"},
+	}
+	for _, value := range values {
+		want, err := json.Marshal(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enc := getJSONEncoder()
+		if err = enc.appendInterface(value); err != nil {
+			t.Fatalf("%T(%v): %v", value, value, err)
+		}
+		if got := string(enc.buf); got != string(want) {
+			t.Errorf("%T(%v): got %s, want %s", value, value, got, want)
+		}
+		putJSONEncoder(enc)
+	}
+
+	// 非法的 json.Number 与 encoding/json 一样返回错误
+	enc := getJSONEncoder()
+	defer putJSONEncoder(enc)
+	if err := enc.appendInterface(json.Number("1x")); err == nil {
+		t.Fatalf("invalid json.Number encoded as %s", enc.buf)
+	}
+}
+
+func BenchmarkJSONFormatter(b *testing.B) {
+	ftr := NewJSONFormatter()
+	info := newBenchLogInfo("GET /api/v1/users?id=1024 200 \"curl/8.0\" 12ms")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ftr.Format(INFO, "INFO", info)
+	}
+}
+
+func BenchmarkJSONFormatterReflect(b *testing.B) {
+	info := newBenchLogInfo("GET /api/v1/users?id=1024 200 \"curl/8.0\" 12ms")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = reflectJSONFormat("INFO", info)
+	}
+}
+
+func newSchemaLogInfo(tag string) *LogInfo {
+	return &LogInfo{
+		Tag:       tag,
+		Time:      time.Date(2026, 10, 12, 15, 4, 5, 123000000, time.UTC),
+		Level:     WARN,
+		Body:      "slow",
+		File:      "a.go",
+		Func:      "f",
+		Line:      7,
+		Fields:    []Field{String("sql", "select 1"), Int("rows", 3)},
+		Goroutine: 9,
+	}
+}
+
+func TestJSONFormatterPresets(t *testing.T) {
+	host, _ := json.Marshal(hostname)
+	cases := []struct {
+		name string
+		ftr  *JSONFormatter
+		tag  string
+		want string
+	}{
+		{
+			"default", NewJSONFormatter(), "db",
+			`{"tag":"db","timestamp":"2026-10-12 15:04:05.123","level":"WARN","func":"a.go:7 (f)","message":"slow","sql":"select 1","rows":3}`,
+		},
+		{
+			"default without tag", NewJSONFormatter(), "",
+			`{"tag":"","timestamp":"2026-10-12 15:04:05.123","level":"WARN","func":"a.go:7 (f)","message":"slow","sql":"select 1","rows":3}`,
+		},
+		{
+			"ecs", NewECSFormatter(), "db",
+			`{"log.logger":"db","@timestamp":"2026-10-12T15:04:05.123Z","log.level":"warn","log.origin.file.name":"a.go","log.origin.file.line":7,"log.origin.function":"f","message":"slow","sql":"select 1","rows":3,` +
+				`"process.pid":` + strconv.Itoa(pid) + `,"host.hostname":` + string(host) + `,"process.thread.id":9,"ecs.version":"1.6.0"}`,
+		},
+		{
+			"ecs omits empty tag", NewECSFormatter(), "",
+			`{"@timestamp":"2026-10-12T15:04:05.123Z","log.level":"warn","log.origin.file.name":"a.go","log.origin.file.line":7,"log.origin.function":"f","message":"slow","sql":"select 1","rows":3,` +
+				`"process.pid":` + strconv.Itoa(pid) + `,"host.hostname":` + string(host) + `,"process.thread.id":9,"ecs.version":"1.6.0"}`,
+		},
+		{
+			"gelf", NewGELFFormatter(), "db",
+			`{"_tag":"db","timestamp":1791817445.123,"level":4,"_file":"a.go","_line":7,"_function":"f","short_message":"slow","_sql":"select 1","_rows":3,` +
+				`"_pid":` + strconv.Itoa(pid) + `,"host":` + string(host) + `,"_goroutine":9,"version":"1.1"}`,
+		},
+		{
+			"gcp", NewGCPFormatter(), "db",
+			`{"tag":"db","time":"2026-10-12T15:04:05.123Z","severity":"WARNING","logging.googleapis.com/sourceLocation":{"file":"a.go","line":7,"function":"f"},"message":"slow","sql":"select 1","rows":3}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.ftr.Format(WARN, "WARN", newSchemaLogInfo(c.tag))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != c.want+"\n" {
+				t.Fatalf("\n got %s\nwant %s", got, c.want)
+			}
+			if !json.Valid(got) {
+				t.Fatalf("invalid json: %s", got)
+			}
+		})
+	}
+}
+
+func TestJSONFormatterFieldConflict(t *testing.T) {
+	fields := []Field{
+		String("message", "user"), String("@timestamp", "now"), String("severity", "low"),
+		String("tag", "web"), String("_tag", "raw"), String("sql", "select 1"),
+	}
+	cases := []struct {
+		name string
+		ftr  *JSONFormatter
+		want map[string]string
+	}{
+		{
+			"ecs", NewECSFormatter(),
+			map[string]string{"message": "slow", "fields.message": "user", "fields.@timestamp": "now", "severity": "low", "tag": "web", "sql": "select 1"},
+		},
+		{
+			"gelf", NewGELFFormatter(),
+			map[string]string{"short_message": "slow", "_message": "user", "_fields.tag": "web", "__tag": "raw", "_tag": "db", "_sql": "select 1"},
+		},
+		{
+			"gcp", NewGCPFormatter(),
+			map[string]string{"message": "slow", "fields.message": "user", "severity": "WARNING", "fields.severity": "low", "tag": "db", "fields.tag": "web"},
+		},
+		{
+			"nested", NewJSONSchemaFormatter(JSONSchema{MessageKey: "message", FieldsKey: "fields"}, ""),
+			map[string]string{"message": "slow"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info := newSchemaLogInfo("db")
+			info.Fields = fields
+			got, err := c.ftr.Format(WARN, "WARN", info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			dec := json.NewDecoder(strings.NewReader(string(got)))
+			if _, err = dec.Token(); err != nil {
+				t.Fatal(err)
+			}
+			seen := make(map[string]bool)
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if seen[key.(string)] {
+					t.Fatalf("duplicate key %q in %s", key, got)
+				}
+				seen[key.(string)] = true
+				var value json.RawMessage
+				if err = dec.Decode(&value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var values map[string]interface{}
+			if err = json.Unmarshal(got, &values); err != nil {
+				t.Fatal(err)
+			}
+			for key, want := range c.want {
+				if values[key] != want {
+					t.Errorf("%s = %v, want %q in %s", key, values[key], want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestJSONFormatterTimeLayout(t *testing.T) {
+	cases := []struct {
+		layout string
+		want   string
+	}{
+		{"", `"2026-10-12 15:04:05.123"`},
+		{DatePattern, `"2026-10-12 15:04:05.123"`},
+		{time.RFC3339, `"2026-10-12T15:04:05Z"`},
+		{time.RFC3339Nano, `"2026-10-12T15:04:05.123Z"`},
+		{"2006/01/02", `"2026/10/12"`},
+		{TimeLayoutEpochMillis, `1791817445123`},
+		{TimeLayoutEpochSeconds, `1791817445.123`},
+	}
+	for _, c := range cases {
+		ftr := NewJSONSchemaFormatter(JSONSchema{TimeKey: "t"}, c.layout)
+		got, err := ftr.Format(WARN, "WARN", newSchemaLogInfo("db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"t":` + c.want + `,"sql":"select 1","rows":3}` + "\n"; string(got) != want {
+			t.Errorf("layout %q: got %s, want %s", c.layout, got, want)
+		}
+	}
+}
+
+func TestJSONFormatterSchemaOptions(t *testing.T) {
+	schema := JSONSchema{
+		MessageKey:  "msg",
+		FieldsKey:   "fields",
+		FieldPrefix: "x_",
+		LevelKey:    "lvl",
+		LevelValue: func(level Level, levelName string) interface{} {
+			return level.Priority()
+		},
+		Static: []JSONField{{Key: "service", Value: "api"}, {Key: "shard", Value: 2}},
+	}
+	got, err := NewJSONSchemaFormatter(schema, "").Format(WARN, "WARN", newSchemaLogInfo("db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"lvl":` + strconv.Itoa(WARN.Priority()) + `,"msg":"slow","fields":{"x_sql":"select 1","x_rows":3},"service":"api","shard":2}` + "\n"
+	if string(got) != want {
+		t.Fatalf("\n got %s\nwant %s", got, want)
+	}
+
+	pretty, err := NewJSONPrettyFormatter().Format(WARN, "WARN", newSchemaLogInfo("db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(pretty), "{\n\t\"tag\": \"db\",\n") || !json.Valid(pretty) {
+		t.Fatalf("pretty = %s", pretty)
+	}
+}
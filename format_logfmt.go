@@ -0,0 +1,143 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 15:32
+// version: 1.0.0
+// desc   : logfmt 格式化
+
+package gog
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// LogfmtKeys logfmt 各字段的键名，为空时不输出该字段
+type LogfmtKeys struct {
+	Time    string // 时间
+	Level   string // 日志级别
+	Tag     string // 标签，标签为空时不输出
+	Caller  string // 发生地 file:line
+	Func    string // 发生地函数
+	Message string // 日志详情
+	Stack   string // 调用栈
+}
+
+// LogfmtFormatter logfmt 格式化，结构化字段追加在日志详情之后
+type LogfmtFormatter struct {
+	TimeLayout string     // 日期时间格式
+	Keys       LogfmtKeys // 字段键名
+}
+
+// NewLogfmtFormatter 创建 logfmt 格式化对象
+func NewLogfmtFormatter() *LogfmtFormatter {
+	return &LogfmtFormatter{
+		TimeLayout: time.RFC3339Nano,
+		Keys: LogfmtKeys{
+			Time:    "time",
+			Level:   "level",
+			Tag:     "tag",
+			Caller:  "caller",
+			Func:    "func",
+			Message: "msg",
+			Stack:   "stack",
+		},
+	}
+}
+
+// Format 具体的格式化定义
+func (lf *LogfmtFormatter) Format(level Level, levelName string, info *LogInfo) ([]byte, error) {
+	layout := lf.TimeLayout
+	if layout == "" {
+		layout = time.RFC3339Nano
+	}
+
+	buf := make([]byte, 0, 128+len(info.Body))
+	buf = appendLogfmtPair(buf, lf.Keys.Time, info.Time.Format(layout))
+	buf = appendLogfmtPair(buf, lf.Keys.Level, levelName)
+	if info.Tag != "" {
+		buf = appendLogfmtPair(buf, lf.Keys.Tag, info.Tag)
+	}
+	if info.File != "" {
+		buf = appendLogfmtPair(buf, lf.Keys.Caller, info.File+":"+strconv.Itoa(info.Line))
+	}
+	if info.Func != "" {
+		buf = appendLogfmtPair(buf, lf.Keys.Func, info.Func)
+	}
+	buf = appendLogfmtPair(buf, lf.Keys.Message, info.Body)
+	for _, field := range info.Fields {
+		buf = appendLogfmtPair(buf, field.Key, field.ValueString())
+	}
+	if len(info.Stack) > 0 {
+		buf = appendLogfmtPair(buf, lf.Keys.Stack, stackString(info.Stack, ""))
+	}
+	return append(buf, '\n'), nil
+}
+
+// appendLogfmtPair 追加 key=value，key 为空时忽略
+func appendLogfmtPair(buf []byte, key, value string) []byte {
+	if key == "" {
+		return buf
+	}
+	if len(buf) > 0 {
+		buf = append(buf, ' ')
+	}
+	buf = appendLogfmtKey(buf, key)
+	buf = append(buf, '=')
+	return appendLogfmtValue(buf, value)
+}
+
+// appendLogfmtKey 追加 key，空格、引号、等号、控制字符等无法出现在键名中的字符替换为 '_'
+func appendLogfmtKey(buf []byte, key string) []byte {
+	if !logfmtNeedsQuote(key) {
+		return append(buf, key...)
+	}
+	for _, r := range key {
+		if logfmtInvalidRune(r) {
+			buf = append(buf, '_')
+			continue
+		}
+		buf = append(buf, string(r)...)
+	}
+	return buf
+}
+
+// appendLogfmtValue 追加 value，包含空格、引号、等号或控制字符时加引号并转义
+func appendLogfmtValue(buf []byte, value string) []byte {
+	if !logfmtNeedsQuote(value) {
+		return append(buf, value...)
+	}
+	return strconv.AppendQuote(buf, value)
+}
+
+// logfmtNeedsQuote 判断 value 是否需要加引号
+func logfmtNeedsQuote(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if logfmtInvalidRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// logfmtInvalidRune 判断字符是否不能直接出现在 logfmt 的键或值中
+func logfmtInvalidRune(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == '\\' || r == 0x7f || r == utf8.RuneError
+}
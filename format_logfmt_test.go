@@ -0,0 +1,98 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 15:32
+// version: 1.0.0
+// desc   : logfmt 格式化测试
+
+package gog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogfmtFormat(t *testing.T) {
+	cases := []struct {
+		name   string
+		key    string
+		value  string
+		output string
+	}{
+		{"plain", "k", "v", `k=v`},
+		{"empty value", "k", "", `k=""`},
+		{"space", "k", "a b", `k="a b"`},
+		{"quote", "k", `say "hi"`, `k="say \"hi\""`},
+		{"equals", "k", "a=b", `k="a=b"`},
+		{"newline", "k", "a\nb", `k="a\nb"`},
+		{"backslash", "k", `a\b`, `k="a\\b"`},
+		{"unicode", "k", "日志", `k=日志`},
+		{"unicode with space", "k", "日 志", `k="日 志"`},
+		{"invalid utf8", "k", "a\xffb", `k="a\xffb"`},
+		{"key with space", "user id", "1", `user_id=1`},
+		{"key with equals", "a=b", "1", `a_b=1`},
+		{"key with quote", `a"b`, "1", `a_b=1`},
+		{"key with newline", "a\nb", "1", `a_b=1`},
+		{"unicode key", "键", "1", `键=1`},
+		{"unicode key with space", "键 名", "1", `键_名=1`},
+	}
+
+	ftr := NewLogfmtFormatter()
+	ftr.Keys = LogfmtKeys{Message: "msg"}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info := &LogInfo{Time: time.Now(), Level: INFO, Body: "m", Fields: []Field{String(c.key, c.value)}}
+			data, err := ftr.Format(INFO, "INFO", info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(data), "msg=m "+c.output+"\n"; got != want {
+				t.Fatalf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLogfmtFormatKeys(t *testing.T) {
+	info := &LogInfo{
+		Time:  time.Date(2026, 10, 13, 9, 50, 0, 0, time.UTC),
+		Level: WARN,
+		Tag:   "db",
+		File:  "format_logfmt_test.go",
+		Line:  7,
+		Func:  "TestLogfmtFormatKeys",
+		Body:  "slow query",
+	}
+	data, err := NewLogfmtFormatter().Format(WARN, "WARN", info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `time=2026-10-13T09:50:00Z level=WARN tag=db caller=format_logfmt_test.go:7 func=TestLogfmtFormatKeys msg="slow query"` + "\n"
+	if string(data) != want {
+		t.Fatalf("got %q, want %q", data, want)
+	}
+
+	// 键名为空时不输出该字段
+	ftr := NewLogfmtFormatter()
+	ftr.Keys.Time = ""
+	ftr.Keys.Func = ""
+	ftr.Keys.Caller = "at"
+	data, _ = ftr.Format(WARN, "WARN", info)
+	want = `level=WARN tag=db at=format_logfmt_test.go:7 msg="slow query"` + "\n"
+	if string(data) != want {
+		t.Fatalf("got %q, want %q", data, want)
+	}
+}
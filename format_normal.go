@@ -22,14 +22,20 @@ package gog
 
 import (
 	"github.com/yhyzgn/gog/util"
+	"github.com/yhyzgn/golus"
 	"strconv"
 	"strings"
 )
 
 // NormalFormatter 控制台格式化
+//
+// 由 Gog 输出时是否上色由输出目标决定，只有终端（或设置了 FORCE_COLOR）才会输出颜色；
+// 直接调用 Format 时设置了 NO_COLOR 环境变量则不输出颜色
 type NormalFormatter struct {
-	Colorful   bool   // 是否支持五颜六色
-	TimeLayout string // 日期时间格式
+	Colorful   bool       // 是否支持五颜六色
+	TimeLayout string     // 日期时间格式
+	Scope      ColorScope // 上色范围，默认整行使用级别颜色
+	Theme      *Theme     // 色彩主题，为 nil 时使用默认主题
 }
 
 // newNormalFormatter 创建控制台格式化对象
@@ -50,21 +56,79 @@ func NewNormalColorfulFormatter() *NormalFormatter {
 	return newNormalFormatter(true)
 }
 
+// NewNormalThemeFormatter 创建按主题为各部分分别上色的控制台格式化对象
+func NewNormalThemeFormatter(theme *Theme) *NormalFormatter {
+	ftr := newNormalFormatter(true)
+	ftr.Scope = ColorSegment
+	ftr.Theme = theme
+	return ftr
+}
+
 // Format 具体的格式化定义
 func (cf *NormalFormatter) Format(level Level, levelName string, info *LogInfo) ([]byte, error) {
+	return cf.format(level, levelName, info, cf.Colorful && !envNoColor())
+}
+
+// FormatColor 按输出目标是否支持颜色格式化，Colorful 为 false 时始终不上色
+func (cf *NormalFormatter) FormatColor(level Level, levelName string, info *LogInfo, colorful bool) ([]byte, error) {
+	return cf.format(level, levelName, info, cf.Colorful && colorful)
+}
+
+// format 格式化日志，colorful 表示是否上色
+func (cf *NormalFormatter) format(level Level, levelName string, info *LogInfo, colorful bool) ([]byte, error) {
+	theme := cf.Theme
+	if theme == nil {
+		theme = defaultTheme
+	}
+	// 只有按部分上色时才使用对应部分的样式
+	segment := func(stylus *golus.Stylus, text string) string {
+		if colorful && cf.Scope == ColorSegment {
+			return paint(stylus, text)
+		}
+		return text
+	}
+
+	layout := cf.TimeLayout
+	if layout == "" {
+		layout = DatePattern
+	}
+
 	var sb strings.Builder
-	sb.WriteString(info.Time.Format(DatePattern))
-	sb.WriteString(WithConnectors(levelName, " ", 8))
-	sb.WriteString(WithConnectors(info.File, " ", util.If(info.ShortFile, FileLengthRel, FileLengthAbs).(int)))
-	sb.WriteString(":" + util.FillSuffix(strconv.Itoa(info.Line), " ", 4))
-	sb.WriteString("(" + info.Func + ")")
+	sb.WriteString(segment(theme.Time, info.Time.Format(layout)))
+
+	// 级别标识前的空白不上色
+	padded := WithConnectors(levelName, " ", 8)
+	sb.WriteString(padded[:len(padded)-len(levelName)])
+	if colorful && cf.Scope != ColorLine {
+		sb.WriteString(paint(theme.Level(level), levelName))
+	} else {
+		sb.WriteString(levelName)
+	}
+
+	padded = WithConnectors(info.File, " ", util.If(info.ShortFile, FileLengthRel, FileLengthAbs).(int))
+	sb.WriteString(padded[:len(padded)-len(info.File)])
+	line := strconv.Itoa(info.Line)
+	sb.WriteString(segment(theme.File, info.File+":"+line))
+	sb.WriteString(util.FillSuffix("", " ", 4-len(line)))
+	sb.WriteString(segment(theme.Func, "("+info.Func+")"))
 	if info.Tag != "" {
-		sb.WriteString("[" + info.Tag + "]")
+		sb.WriteString(segment(theme.Tag, "["+info.Tag+"]"))
+	}
+	sb.WriteString(segment(theme.Body, info.Body))
+	for _, field := range info.Fields {
+		sb.WriteString(" ")
+		sb.WriteString(segment(theme.FieldKey, field.Key))
+		sb.WriteString("=")
+		sb.WriteString(segment(theme.FieldValue, string(appendLogfmtValue(nil, field.ValueString()))))
+	}
+
+	if len(info.Stack) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(stackString(info.Stack, "\t"))
 	}
-	sb.WriteString(info.Body)
 
 	res := sb.String()
-	if cf.Colorful {
+	if colorful && cf.Scope == ColorLine {
 		res = Colorful(level).Apply(res)
 	}
 	return []byte(res + "\n"), nil
@@ -33,6 +33,7 @@ func init() {
 	once.Do(func() {
 		// 每多封装一层，就需要多跳过一层调用栈
 		gog = NewGog(ALL, 1)
+		gog.wrapperSkip = 1
 		// 默认只显示文件名，不显示完整路径
 		gog.ShortFile(true)
 	})
@@ -91,6 +92,21 @@ func ShortFile(short bool) {
 	gog.ShortFile(short)
 }
 
+// SetCallerMode 设置发生地文件的显示方式
+func SetCallerMode(mode CallerMode) {
+	gog.CallerMode(mode)
+}
+
+// SetFuncMode 设置发生地函数的显示方式
+func SetFuncMode(mode FuncMode) {
+	gog.FuncMode(mode)
+}
+
+// SetStackLevel 设置记录调用栈的最低日志级别，OFF 表示不记录
+func SetStackLevel(lvl Level) {
+	gog.StackLevel(lvl)
+}
+
 // Async 是否启用异步
 //
 // 默认关闭
@@ -98,6 +114,71 @@ func Async(async bool) {
 	gog.Async(async)
 }
 
+// SetRedactor 设置敏感信息脱敏处理器
+func SetRedactor(redactor *Redactor) {
+	gog.Redactor(redactor)
+}
+
+// Sync 等待日志全部输出，并刷新、同步所有输出器，程序退出前应调用
+func Sync() error {
+	return gog.Sync()
+}
+
+// Close 同步并关闭所有输出器
+func Close() error {
+	return gog.Close()
+}
+
+// SetTagFilter 设置标签过滤规则，如 "*:INFO,db:DEBUG,noisy:OFF"
+func SetTagFilter(spec string) error {
+	return gog.SetTagFilter(spec)
+}
+
+// SetMetrics 设置指标统计
+func SetMetrics(metrics *Metrics) {
+	gog.Metrics(metrics)
+}
+
+// AddHook 添加钩子
+func AddHook(hook ...Hook) {
+	gog.AddHook(hook...)
+}
+
+// Use 添加处理链中间件
+func Use(middleware ...Middleware) {
+	gog.Use(middleware...)
+}
+
+// Enabled 指定级别的日志是否会被输出
+func Enabled(lvl Level) bool {
+	return gog.Enabled(lvl)
+}
+
+// IsTraceEnabled TRACE 级别日志是否会被输出
+func IsTraceEnabled() bool {
+	return gog.IsTraceEnabled()
+}
+
+// IsDebugEnabled DEBUG 级别日志是否会被输出
+func IsDebugEnabled() bool {
+	return gog.IsDebugEnabled()
+}
+
+// IsInfoEnabled INFO 级别日志是否会被输出
+func IsInfoEnabled() bool {
+	return gog.IsInfoEnabled()
+}
+
+// IsWarnEnabled WARN 级别日志是否会被输出
+func IsWarnEnabled() bool {
+	return gog.IsWarnEnabled()
+}
+
+// IsErrorEnabled ERROR 级别日志是否会被输出
+func IsErrorEnabled() bool {
+	return gog.IsErrorEnabled()
+}
+
 // Trace 追踪打印
 func Trace(value ...interface{}) {
 	gog.Trace(value...)
@@ -218,54 +299,12 @@ func FatalTagF(tag string, format string, args ...interface{}) {
 	gog.FatalTagF(tag, format, args...)
 }
 
-// Log 适配器
+// Log 适配器，支持自定义日志级别
 func Log(level Level, args ...interface{}) {
-	switch level {
-	case TRACE:
-		gog.Trace(args...)
-		break
-	case DEBUG:
-		gog.Debug(args...)
-		break
-	case INFO:
-		gog.Info(args...)
-		break
-	case WARN:
-		gog.Warn(args...)
-		break
-	case ERROR:
-		gog.Error(args...)
-		break
-	case FATAL:
-		gog.Fatal(args...)
-		break
-	default:
-		gog.Info(args...)
-	}
-}
-
-// LogF 适配器
+	gog.Log(level, args...)
+}
+
+// LogF 适配器，支持自定义日志级别
 func LogF(level Level, format string, args ...interface{}) {
-	switch level {
-	case TRACE:
-		gog.TraceF(format, args...)
-		break
-	case DEBUG:
-		gog.DebugF(format, args...)
-		break
-	case INFO:
-		gog.InfoF(format, args...)
-		break
-	case WARN:
-		gog.WarnF(format, args...)
-		break
-	case ERROR:
-		gog.ErrorF(format, args...)
-		break
-	case FATAL:
-		gog.FatalF(format, args...)
-		break
-	default:
-		gog.InfoF(format, args...)
-	}
+	gog.LogF(level, format, args...)
 }
@@ -23,6 +23,7 @@ package gog
 import (
 	"fmt"
 	"os"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -66,6 +67,66 @@ func test() {
 	TraceF("Hello, {} !", "gog")
 }
 
+func TestAsyncClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	rw := NewRingWriter(200)
+	g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{rw}}).Async(true)
+	for i := 0; i < 100; i++ {
+		g.Info("async")
+	}
+	if err := g.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if rw.Len() != 100 {
+		t.Fatalf("records = %d, want 100", rw.Len())
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines = %d, want <= %d", after, before)
+	}
+
+	// 关闭后同步输出
+	g.Info("sync")
+	if rw.Len() != 101 {
+		t.Fatalf("records = %d, want 101", rw.Len())
+	}
+}
+
+func TestAsyncSyncReentrant(t *testing.T) {
+	rw := NewRingWriter(10)
+	g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter()}).Async(true)
+	// 输出器在异步输出协程中再调用 Sync，如 FATAL 日志退出前的同步
+	g.SetWriter(rw, &syncingWriter{g: g})
+	done := make(chan struct{})
+	go func() {
+		g.Info("first")
+		g.Info("second")
+		_ = g.Sync()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Sync deadlocked on the async goroutine")
+	}
+	if rw.Len() != 2 {
+		t.Fatalf("records = %d, want 2", rw.Len())
+	}
+	_ = g.Close()
+}
+
+// syncingWriter 每次输出时同步日志处理器
+type syncingWriter struct {
+	g *Gog
+}
+
+func (sw *syncingWriter) Write(_ *LogInfo, data []byte) (int, error) {
+	return len(data), sw.g.Sync()
+}
+
+func (sw *syncingWriter) Close() error {
+	return nil
+}
+
 type testWriter struct {
 	ConsoleWriter
 }
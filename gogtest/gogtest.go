@@ -0,0 +1,218 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 11:03
+// version: 1.0.0
+// desc   : 单元测试辅助工具
+
+// Package gogtest 提供单元测试中观察和断言日志输出的工具
+package gogtest
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yhyzgn/gog"
+)
+
+var (
+	mu        sync.Mutex
+	recorders = make(map[testing.TB]*Recorder) // 每个测试对应的记录器
+)
+
+// Recorder 记录型输出器，保存所有写入的日志记录
+type Recorder struct {
+	mu      sync.Mutex
+	records []gog.LogInfo
+}
+
+// NewRecorder 创建记录型输出器对象
+func NewRecorder() *Recorder {
+	return &Recorder{
+		records: make([]gog.LogInfo, 0),
+	}
+}
+
+// Write 记录日志
+func (r *Recorder) Write(info *gog.LogInfo, data []byte) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, *info)
+	return len(data), nil
+}
+
+// Close 关闭输出器
+func (r *Recorder) Close() error {
+	return nil
+}
+
+// Records 获取已记录的所有日志
+func (r *Recorder) Records() []gog.LogInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]gog.LogInfo{}, r.records...)
+}
+
+// Find 查找满足条件的日志
+//
+// tag 为空时匹配任意标签，bodyContains 为空时匹配任意日志详情
+func (r *Recorder) Find(level gog.Level, tag, bodyContains string) []gog.LogInfo {
+	result := make([]gog.LogInfo, 0)
+	for _, info := range r.Records() {
+		if info.Level != level {
+			continue
+		}
+		if tag != "" && info.Tag != tag {
+			continue
+		}
+		if !strings.Contains(info.Body, bodyContains) {
+			continue
+		}
+		result = append(result, info)
+	}
+	return result
+}
+
+// Reset 清空已记录的日志
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = make([]gog.LogInfo, 0)
+}
+
+// tbWriter 将日志转发到 t.Log 的输出器
+//
+// t.Log 标注的是输出器内部的位置，因此在消息开头写上日志的发生地
+type tbWriter struct {
+	t testing.TB
+}
+
+// Write 输出日志
+func (tw *tbWriter) Write(info *gog.LogInfo, data []byte) (n int, err error) {
+	tw.t.Helper()
+	tw.t.Log(tbMessage(info))
+	return len(data), nil
+}
+
+// tbMessage 生成 "file:line: LEVEL [tag] body key=value" 形式的消息
+func tbMessage(info *gog.LogInfo) string {
+	var sb strings.Builder
+	if info.File != "" {
+		sb.WriteString(info.File)
+		sb.WriteString(":")
+		sb.WriteString(strconv.Itoa(info.Line))
+		sb.WriteString(": ")
+	}
+	sb.WriteString(gog.GetLevelName(info.Level))
+	if info.Tag != "" {
+		sb.WriteString(" [")
+		sb.WriteString(info.Tag)
+		sb.WriteString("]")
+	}
+	sb.WriteString(" ")
+	sb.WriteString(info.Body)
+	for _, field := range info.Fields {
+		value := field.ValueString()
+		if value == "" || strings.ContainsAny(value, " \"=\n") {
+			value = strconv.Quote(value)
+		}
+		sb.WriteString(" ")
+		sb.WriteString(field.Key)
+		sb.WriteString("=")
+		sb.WriteString(value)
+	}
+	return sb.String()
+}
+
+// Close 关闭输出器
+func (tw *tbWriter) Close() error {
+	return nil
+}
+
+// NewTestGog 创建测试专用的日志处理器
+//
+// 日志会输出到 t.Log，同时被记录下来供 AssertLogged 等断言使用，测试结束后自动关闭并释放
+func NewTestGog(t testing.TB) *gog.Gog {
+	t.Helper()
+	recorder := NewRecorder()
+	g := gog.NewGog(gog.ALL, 0).
+		SetConfig(&gog.Config{
+			Formatter: gog.NewNormalFormatter(),
+			Writers:   []gog.Writer{recorder, &tbWriter{t: t}},
+		}).
+		ShortFile(true)
+
+	mu.Lock()
+	recorders[t] = recorder
+	mu.Unlock()
+	t.Cleanup(func() {
+		_ = g.Close()
+		mu.Lock()
+		delete(recorders, t)
+		mu.Unlock()
+	})
+	return g
+}
+
+// RecorderOf 获取 NewTestGog(t) 创建的记录器
+func RecorderOf(t testing.TB) *Recorder {
+	t.Helper()
+	mu.Lock()
+	recorder, ok := recorders[t]
+	mu.Unlock()
+	if !ok {
+		t.Fatal("gogtest: NewTestGog(t) must be called before asserting")
+	}
+	return recorder
+}
+
+// AssertLogged 断言至少输出过一条满足条件的日志
+//
+// tag 为空时匹配任意标签，bodyContains 为空时匹配任意日志详情
+func AssertLogged(t testing.TB, level gog.Level, tag, bodyContains string) bool {
+	t.Helper()
+	if len(RecorderOf(t).Find(level, tag, bodyContains)) > 0 {
+		return true
+	}
+	t.Errorf("gogtest: no %s log with tag %q containing %q", gog.GetLevelName(level), tag, bodyContains)
+	return false
+}
+
+// AssertNotLogged 断言没有输出过满足条件的日志
+func AssertNotLogged(t testing.TB, level gog.Level, tag, bodyContains string) bool {
+	t.Helper()
+	found := RecorderOf(t).Find(level, tag, bodyContains)
+	if len(found) == 0 {
+		return true
+	}
+	t.Errorf("gogtest: unexpected %s log with tag %q: %s", gog.GetLevelName(level), found[0].Tag, found[0].Body)
+	return false
+}
+
+// AssertNoErrors 断言没有输出过 ERROR 及以上级别的日志
+func AssertNoErrors(t testing.TB) bool {
+	t.Helper()
+	ok := true
+	for _, info := range RecorderOf(t).Records() {
+		if info.Level != gog.OFF && info.Level.AtLeast(gog.ERROR) {
+			t.Errorf("gogtest: unexpected %s log: %s", gog.GetLevelName(info.Level), info.Body)
+			ok = false
+		}
+	}
+	return ok
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 11:03
+// version: 1.0.0
+// desc   : 单元测试辅助工具
+
+package gogtest
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/yhyzgn/gog"
+)
+
+func TestNewTestGog(t *testing.T) {
+	g := NewTestGog(t)
+	g.InfoTag("db", "connected to ", "mysql")
+	g.Debug("plain")
+
+	AssertLogged(t, gog.INFO, "db", "mysql")
+	AssertLogged(t, gog.DEBUG, "", "plain")
+	AssertNotLogged(t, gog.WARN, "", "")
+	AssertNoErrors(t)
+
+	if records := RecorderOf(t).Records(); len(records) != 2 || records[0].File != "gogtest_test.go" {
+		t.Fatalf("unexpected records: %v", records)
+	}
+}
+
+// logTB 记录 t.Log 的消息
+type logTB struct {
+	testing.TB
+	logs []string
+}
+
+func (tb *logTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+func TestTBWriterCaller(t *testing.T) {
+	tb := &logTB{TB: t}
+	g := NewTestGog(tb).Async(true)
+	_, _, line, _ := runtime.Caller(0)
+	g.InfoTag("db", "query", gog.Int("rows", 3), gog.String("sql", "select 1"))
+	if err := g.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf(`gogtest_test.go:%d: INFO [db] query rows=3 sql="select 1"`, line+1)
+	if len(tb.logs) != 1 || tb.logs[0] != want {
+		t.Fatalf("logs = %q, want %q", tb.logs, want)
+	}
+}
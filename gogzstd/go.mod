@@ -0,0 +1,5 @@
+module github.com/yhyzgn/gog/gogzstd
+
+go 1.17
+
+require github.com/klauspost/compress v1.15.15
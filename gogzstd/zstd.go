@@ -0,0 +1,59 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-24 10:20
+// version: 1.0.0
+// desc   : zstd 压缩算法
+
+// Package gogzstd 为 gog.CompressWriter 提供 zstd 压缩算法
+//
+// 独立为子模块，只有使用 zstd 的程序才需要引入 github.com/klauspost/compress；
+// Codec 实现了 gog.Codec 接口，本模块不依赖 gog：
+//
+//	cw := gog.NewCompressWriter(fw, gogzstd.New(gogzstd.SpeedDefault))
+package gogzstd
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// SpeedFastest 最快压缩
+	SpeedFastest = zstd.SpeedFastest
+	// SpeedDefault 默认压缩级别
+	SpeedDefault = zstd.SpeedDefault
+	// SpeedBetterCompression 更高压缩率
+	SpeedBetterCompression = zstd.SpeedBetterCompression
+	// SpeedBestCompression 最高压缩率
+	SpeedBestCompression = zstd.SpeedBestCompression
+)
+
+// Codec zstd 压缩算法，每块为一个 zstd frame，拼接后仍是合法的 zstd 文件
+type Codec struct {
+	level zstd.EncoderLevel
+}
+
+// New 创建 zstd 压缩算法
+func New(level zstd.EncoderLevel) *Codec {
+	return &Codec{level: level}
+}
+
+// NewEncoder 创建编码器
+func (c *Codec) NewEncoder(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(c.level), zstd.WithEncoderConcurrency(1))
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-24 10:20
+// version: 1.0.0
+// desc   : zstd 压缩算法测试
+
+package gogzstd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestCodec(t *testing.T) {
+	codec := New(SpeedDefault)
+	var blocks [][]byte
+	for _, line := range []string{"first line\n", "second\n"} {
+		var block bytes.Buffer
+		enc, err := codec.NewEncoder(&block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = enc.Write([]byte(line))
+		if err = enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, block.Bytes())
+	}
+
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	// 每块可以独立解压，拼接后也是合法的 zstd 文件
+	for i, want := range []string{"first line\n", "second\n"} {
+		if data, err := dec.DecodeAll(blocks[i], nil); err != nil || string(data) != want {
+			t.Fatalf("block %d = %q, %v", i, data, err)
+		}
+	}
+	if data, err := dec.DecodeAll(bytes.Join(blocks, nil), nil); err != nil || string(data) != "first line\nsecond\n" {
+		t.Fatalf("joined = %q, %v", data, err)
+	}
+}
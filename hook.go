@@ -0,0 +1,96 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-21 10:05
+// version: 1.0.0
+// desc   : 钩子与处理链
+
+package gog
+
+// Hook 日志钩子，在日志交给输出器之前触发
+type Hook interface {
+	// Levels 需要触发钩子的日志级别，为空时所有级别都触发
+	Levels() []Level
+	// Fire 触发钩子，可以修改日志记录，返回的错误只会被打印
+	Fire(info *LogInfo) error
+}
+
+// Handler 日志记录处理函数
+type Handler func(info *LogInfo)
+
+// Middleware 日志处理中间件
+//
+// 可以修改记录后调用 next，不调用 next 即丢弃记录，多次调用 next 即复制记录
+type Middleware func(next Handler) Handler
+
+// hookFunc 函数形式的钩子
+type hookFunc struct {
+	levels []Level
+	fire   func(info *LogInfo) error
+}
+
+// NewHook 使用函数创建钩子，levels 为空时所有级别都触发
+func NewHook(fire func(info *LogInfo) error, levels ...Level) Hook {
+	return &hookFunc{
+		levels: levels,
+		fire:   fire,
+	}
+}
+
+// Levels 需要触发钩子的日志级别
+func (hf *hookFunc) Levels() []Level {
+	return hf.levels
+}
+
+// Fire 触发钩子
+func (hf *hookFunc) Fire(info *LogInfo) error {
+	return hf.fire(info)
+}
+
+// EnrichMiddleware 为每条日志追加固定字段的中间件
+func EnrichMiddleware(fields ...Field) Middleware {
+	return func(next Handler) Handler {
+		return func(info *LogInfo) {
+			info.Fields = append(info.Fields, fields...)
+			next(info)
+		}
+	}
+}
+
+// FilterMiddleware 只保留 keep 返回 true 的日志的中间件
+func FilterMiddleware(keep func(info *LogInfo) bool) Middleware {
+	return func(next Handler) Handler {
+		return func(info *LogInfo) {
+			if keep(info) {
+				next(info)
+			}
+		}
+	}
+}
+
+// hookMatches 判断钩子是否需要在该级别触发
+func hookMatches(hook Hook, level Level) bool {
+	levels := hook.Levels()
+	if len(levels) == 0 {
+		return true
+	}
+	for _, lvl := range levels {
+		if lvl == level {
+			return true
+		}
+	}
+	return false
+}
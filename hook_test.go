@@ -0,0 +1,155 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-21 10:05
+// version: 1.0.0
+// desc   : 钩子与处理链测试
+
+package gog
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newHookTestGog() (*Gog, *bytesWriter) {
+	out := &bytesWriter{}
+	lf := NewLogfmtFormatter()
+	lf.Keys = LogfmtKeys{Level: "level", Message: "msg"}
+	return NewGog(TRACE, 0).SetConfig(&Config{Formatter: lf, Writers: []Writer{out}}), out
+}
+
+func TestHooks(t *testing.T) {
+	g, out := newHookTestGog()
+	var all, errs []string
+	g.AddHook(NewHook(func(info *LogInfo) error {
+		all = append(all, info.Body)
+		return nil
+	}))
+	g.AddHook(NewHook(func(info *LogInfo) error {
+		errs = append(errs, info.Body)
+		info.Fields = append(info.Fields, String("alert", "yes"))
+		return errors.New("hook errors are only printed")
+	}, WARN, ERROR))
+
+	g.Debug("d")
+	g.Info("i")
+	g.Warn("w")
+	g.Error("e")
+
+	if got := strings.Join(all, ","); got != "d,i,w,e" {
+		t.Fatalf("all levels hook = %q", got)
+	}
+	if got := strings.Join(errs, ","); got != "w,e" {
+		t.Fatalf("level filtered hook = %q", got)
+	}
+	want := "level=DEBUG msg=d\nlevel=INFO msg=i\nlevel=WARN msg=w alert=yes\nlevel=ERROR msg=e alert=yes\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+
+	// 清除后不再触发
+	g.ResetHooks()
+	g.Warn("after reset")
+	if len(all) != 4 || len(errs) != 2 {
+		t.Fatalf("hooks fired after reset: %v %v", all, errs)
+	}
+}
+
+func TestMiddlewares(t *testing.T) {
+	g, out := newHookTestGog()
+	duplicate := func(next Handler) Handler {
+		return func(info *LogInfo) {
+			if info.Tag == "twice" {
+				dup := *info
+				next(&dup)
+			}
+			next(info)
+		}
+	}
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(info *LogInfo) {
+				order = append(order, name)
+				next(info)
+			}
+		}
+	}
+	g.Use(trace("first"), trace("second"))
+	g.Use(
+		FilterMiddleware(func(info *LogInfo) bool { return info.Body != "drop" }),
+		duplicate,
+		EnrichMiddleware(String("app", "api"), Int("pid", 1)),
+	)
+	// 钩子在中间件之后触发，被丢弃的日志不会触发钩子
+	hooked := 0
+	g.AddHook(NewHook(func(info *LogInfo) error {
+		hooked++
+		return nil
+	}))
+
+	g.Info("keep")
+	g.Info("drop")
+	g.InfoTag("twice", "dup")
+
+	want := "level=INFO msg=keep app=api pid=1\nlevel=INFO msg=dup app=api pid=1\nlevel=INFO msg=dup app=api pid=1\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+	if hooked != 3 {
+		t.Fatalf("hooked = %d, want 3", hooked)
+	}
+	if got := strings.Join(order, ","); got != "first,second,first,second,first,second" {
+		t.Fatalf("order = %q", got)
+	}
+
+	// 派生的日志处理器继承中间件，之后的修改互不影响
+	derived := g.WithCallerSkip(0)
+	g.ResetHooks()
+	out.Reset()
+	derived.Info("drop")
+	g.Info("drop")
+	if out.String() != "level=INFO msg=drop\n" {
+		t.Fatalf("got %q", out.String())
+	}
+}
+
+func TestHooksConcurrent(t *testing.T) {
+	g, _ := newHookTestGog()
+	g.SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{NewRingWriter(16)}})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				g.Info("concurrent")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				g.AddHook(NewHook(func(info *LogInfo) error { return nil }))
+				g.Use(EnrichMiddleware(String("k", "v")))
+				g.ResetHooks()
+			}
+		}()
+	}
+	wg.Wait()
+}
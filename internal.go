@@ -39,20 +39,74 @@ type Formatter interface {
 	Format(level Level, levelName string, info *LogInfo) ([]byte, error)
 }
 
+// goroutineRecorder 需要记录协程 id 的格式化
+type goroutineRecorder interface {
+	recordGoroutine() bool
+}
+
 // Writer 日志输出器
 type Writer interface {
 	io.Closer
 	Write(info *LogInfo, data []byte) (n int, err error)
 }
 
+// Flusher 带缓冲的输出器，Flush 将缓冲中的日志写出
+type Flusher interface {
+	Flush() error
+}
+
+// Syncer 支持同步落盘的输出器
+type Syncer interface {
+	Sync() error
+}
+
+// streamWriter 按字节流输出的输出器，只按级别使用日志数据，
+// 连续的同级别日志可以合并为一次写出，此时只传入最后一条日志的数据
+type streamWriter interface {
+	stream() bool
+}
+
+// NamedWriter 有名称的输出器，名称用于在指标中区分同类型的多个输出器
+type NamedWriter interface {
+	Name() string
+}
+
+// syncWriter 依次刷新并同步输出器
+func syncWriter(w Writer) error {
+	if flusher, ok := w.(Flusher); ok {
+		if err := flusher.Flush(); err != nil {
+			return err
+		}
+	}
+	if syncer, ok := w.(Syncer); ok {
+		return syncer.Sync()
+	}
+	return nil
+}
+
 // LogInfo 日志数据
 type LogInfo struct {
-	Tag       string    // 标签
-	Time      time.Time // 日志产生时间
-	Level     Level     // 日志等级
-	Body      string    // 日志详情
-	File      string    // 发生地文件
-	Func      string    // 发生地函数
-	Line      int       // 发生地行号
-	ShortFile bool      // 是否为短文件名
+	Tag       string       // 标签
+	Time      time.Time    // 日志产生时间
+	Level     Level        // 日志等级
+	Body      string       // 日志详情
+	Fields    []Field      // 结构化字段
+	File      string       // 发生地文件
+	Func      string       // 发生地函数
+	Line      int          // 发生地行号
+	ShortFile bool         // 是否为短文件名
+	Goroutine uint64       // 发生地协程 id，仅在格式化需要时记录
+	Stack     []StackFrame // 调用栈，仅在日志级别达到 StackLevel 时记录
+}
+
+// Clone 复制日志数据，复制后的字段可以独立修改
+func (info *LogInfo) Clone() *LogInfo {
+	clone := *info
+	if info.Fields != nil {
+		clone.Fields = append([]Field{}, info.Fields...)
+	}
+	if info.Stack != nil {
+		clone.Stack = append([]StackFrame{}, info.Stack...)
+	}
+	return &clone
 }
@@ -0,0 +1,263 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 16:47
+// version: 1.0.0
+// desc   : 流式 json 编码器
+
+package gog
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// 编码器对象池
+var jsonEncoderPool = sync.Pool{
+	New: func() interface{} {
+		return &jsonEncoder{buf: make([]byte, 0, 1024)}
+	},
+}
+
+// jsonEncoder 追加式 json 编码器，直接写入缓冲区，不使用反射
+type jsonEncoder struct {
+	buf []byte
+}
+
+// getJSONEncoder 从对象池中获取编码器
+func getJSONEncoder() *jsonEncoder {
+	enc := jsonEncoderPool.Get().(*jsonEncoder)
+	enc.buf = enc.buf[:0]
+	return enc
+}
+
+// putJSONEncoder 将编码器放回对象池，过大的缓冲区直接丢弃
+func putJSONEncoder(enc *jsonEncoder) {
+	if cap(enc.buf) > 64*1024 {
+		return
+	}
+	jsonEncoderPool.Put(enc)
+}
+
+// openObject 开始一个对象
+func (enc *jsonEncoder) openObject() {
+	enc.separator()
+	enc.buf = append(enc.buf, '{')
+}
+
+// closeObject 结束一个对象
+func (enc *jsonEncoder) closeObject() {
+	enc.buf = append(enc.buf, '}')
+}
+
+// openArray 开始一个数组
+func (enc *jsonEncoder) openArray() {
+	enc.separator()
+	enc.buf = append(enc.buf, '[')
+}
+
+// closeArray 结束一个数组
+func (enc *jsonEncoder) closeArray() {
+	enc.buf = append(enc.buf, ']')
+}
+
+// key 写入键名
+func (enc *jsonEncoder) key(key string) {
+	enc.separator()
+	enc.appendQuoted(key)
+	enc.buf = append(enc.buf, ':')
+}
+
+// separator 必要时写入元素之间的逗号
+func (enc *jsonEncoder) separator() {
+	if len(enc.buf) == 0 {
+		return
+	}
+	switch enc.buf[len(enc.buf)-1] {
+	case '{', '[', ':':
+		return
+	}
+	enc.buf = append(enc.buf, ',')
+}
+
+// appendString 写入字符串值
+func (enc *jsonEncoder) appendString(value string) {
+	enc.separator()
+	enc.appendQuoted(value)
+}
+
+// appendInt 写入整数值
+func (enc *jsonEncoder) appendInt(value int64) {
+	enc.separator()
+	enc.buf = strconv.AppendInt(enc.buf, value, 10)
+}
+
+// appendUint 写入无符号整数值
+func (enc *jsonEncoder) appendUint(value uint64) {
+	enc.separator()
+	enc.buf = strconv.AppendUint(enc.buf, value, 10)
+}
+
+// appendBool 写入布尔值
+func (enc *jsonEncoder) appendBool(value bool) {
+	enc.separator()
+	enc.buf = strconv.AppendBool(enc.buf, value)
+}
+
+// appendFloat 写入浮点数值，NaN 和 Inf 以字符串形式输出
+func (enc *jsonEncoder) appendFloat(value float64, bitSize int) {
+	enc.separator()
+	switch {
+	case math.IsNaN(value):
+		enc.buf = append(enc.buf, `"NaN"`...)
+	case math.IsInf(value, 1):
+		enc.buf = append(enc.buf, `"+Inf"`...)
+	case math.IsInf(value, -1):
+		enc.buf = append(enc.buf, `"-Inf"`...)
+	default:
+		// 与 encoding/json 保持一致，过大或过小时使用科学计数法
+		format := byte('f')
+		if abs := math.Abs(value); abs != 0 {
+			if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) || bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+				format = 'e'
+			}
+		}
+		enc.buf = strconv.AppendFloat(enc.buf, value, format, -1, bitSize)
+		if format == 'e' {
+			// 指数只有一位时去掉补齐的 0，如 1e-07 写为 1e-7
+			n := len(enc.buf)
+			if n >= 4 && enc.buf[n-4] == 'e' && enc.buf[n-3] == '-' && enc.buf[n-2] == '0' {
+				enc.buf[n-2] = enc.buf[n-1]
+				enc.buf = enc.buf[:n-1]
+			}
+		}
+	}
+}
+
+// appendRaw 写入已编码的 json 值
+func (enc *jsonEncoder) appendRaw(raw []byte) {
+	enc.separator()
+	enc.buf = append(enc.buf, raw...)
+}
+
+// appendInterface 写入任意值，常见类型直接编码，其余类型使用 encoding/json
+func (enc *jsonEncoder) appendInterface(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		enc.appendRaw([]byte("null"))
+	case string:
+		enc.appendString(v)
+	case bool:
+		enc.appendBool(v)
+	case int:
+		enc.appendInt(int64(v))
+	case int8:
+		enc.appendInt(int64(v))
+	case int16:
+		enc.appendInt(int64(v))
+	case int32:
+		enc.appendInt(int64(v))
+	case int64:
+		enc.appendInt(v)
+	case uint:
+		enc.appendUint(uint64(v))
+	case uint8:
+		enc.appendUint(uint64(v))
+	case uint16:
+		enc.appendUint(uint64(v))
+	case uint32:
+		enc.appendUint(uint64(v))
+	case uint64:
+		enc.appendUint(v)
+	case float32:
+		enc.appendFloat(float64(v), 32)
+	case float64:
+		enc.appendFloat(v, 64)
+	case json.Number:
+		// 与 encoding/json 一致，空值写为 0，其余交给 encoding/json 校验格式
+		if v == "" {
+			enc.appendRaw([]byte("0"))
+			break
+		}
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		enc.appendRaw(raw)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		enc.appendRaw(raw)
+	}
+	return nil
+}
+
+// appendQuoted 写入带引号并转义的字符串，转义规则与 encoding/json 一致
+func (enc *jsonEncoder) appendQuoted(value string) {
+	enc.buf = append(enc.buf, '"')
+	start := 0
+	for i := 0; i < len(value); {
+		if b := value[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			enc.buf = append(enc.buf, value[start:i]...)
+			switch b {
+			case '"', '\\':
+				enc.buf = append(enc.buf, '\\', b)
+			case '\n':
+				enc.buf = append(enc.buf, '\\', 'n')
+			case '\r':
+				enc.buf = append(enc.buf, '\\', 'r')
+			case '\t':
+				enc.buf = append(enc.buf, '\\', 't')
+			default:
+				// 控制字符及 html 敏感字符
+				enc.buf = append(enc.buf, '\\', 'u', '0', '0', hexDigits[b>>4], hexDigits[b&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(value[i:])
+		if r == utf8.RuneError && size == 1 {
+			enc.buf = append(enc.buf, value[start:i]...)
+			enc.buf = append(enc.buf, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		// U+2028 和 U+2029 在 JavaScript 中是换行符
+		if r == '\u2028' || r == '\u2029' {
+			enc.buf = append(enc.buf, value[start:i]...)
+			enc.buf = append(enc.buf, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	enc.buf = append(enc.buf, value[start:]...)
+	enc.buf = append(enc.buf, '"')
+}
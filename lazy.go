@@ -0,0 +1,74 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-20 14:08
+// version: 1.0.0
+// desc   : 延迟求值参数
+
+package gog
+
+import "fmt"
+
+// LazyValue 延迟求值的日志参数
+type LazyValue struct {
+	fn func() interface{}
+}
+
+// Lazy 创建延迟求值的日志参数，只有日志真正输出时才会调用 fn
+//
+// 可以直接作为日志参数，也可以通过 Any(key, Lazy(fn)) 作为字段值
+func Lazy(fn func() interface{}) LazyValue {
+	return LazyValue{fn: fn}
+}
+
+// Value 求值
+func (lv LazyValue) Value() interface{} {
+	if lv.fn == nil {
+		return nil
+	}
+	return lv.fn()
+}
+
+// String 求值并转换为字符串
+func (lv LazyValue) String() string {
+	return fmt.Sprint(lv.Value())
+}
+
+// resolveLazy 对参数中的 LazyValue 求值，没有 LazyValue 时原样返回
+func resolveLazy(args []interface{}) []interface{} {
+	resolved, copied := args, false
+	for i, arg := range args {
+		var value interface{}
+		switch v := arg.(type) {
+		case LazyValue:
+			value = v.Value()
+		case Field:
+			lv, ok := v.Interface.(LazyValue)
+			if !ok || v.Type != StringerType {
+				continue
+			}
+			value = Any(v.Key, lv.Value())
+		default:
+			continue
+		}
+		if !copied {
+			// 首次需要替换时才拷贝，避免修改调用方的切片
+			resolved, copied = append(make([]interface{}, 0, len(args)), args...), true
+		}
+		resolved[i] = value
+	}
+	return resolved
+}
@@ -0,0 +1,81 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-20 14:08
+// version: 1.0.0
+// desc   : 延迟求值参数测试
+
+package gog
+
+import (
+	"testing"
+)
+
+func TestLazyEvaluatedOnlyWhenEnabled(t *testing.T) {
+	calls := 0
+	expensive := func() interface{} {
+		calls++
+		return "computed"
+	}
+
+	out := &bytesWriter{}
+	lf := NewLogfmtFormatter()
+	lf.Keys = LogfmtKeys{Message: "msg"}
+	g := NewGog(WARN, 0).SetConfig(&Config{Formatter: lf, Writers: []Writer{out}})
+
+	g.Debug("value ", Lazy(expensive))
+	g.Info("field", Any("v", Lazy(expensive)))
+	g.InfoTagF("tag", "value {}", Lazy(expensive))
+	if calls != 0 || out.Len() != 0 {
+		t.Fatalf("disabled level evaluated lazy value: calls = %d, out = %q", calls, out.String())
+	}
+
+	g.Warn("value ", Lazy(expensive))
+	g.Error("field", Any("v", Lazy(expensive)))
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+	want := "msg=\"value computed\"\nmsg=field v=computed\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+
+	// 标签被过滤时同样不求值
+	out.Reset()
+	g.TagFilter(MustParseTagFilter("noisy:ERROR"))
+	g.WarnTag("noisy", Lazy(expensive))
+	if calls != 2 || out.Len() != 0 {
+		t.Fatalf("filtered tag evaluated lazy value: calls = %d, out = %q", calls, out.String())
+	}
+}
+
+func TestLazyValue(t *testing.T) {
+	lv := Lazy(func() interface{} { return 42 })
+	if lv.Value() != 42 || lv.String() != "42" {
+		t.Fatalf("value = %v, string = %q", lv.Value(), lv.String())
+	}
+	var zero LazyValue
+	if zero.Value() != nil {
+		t.Fatalf("zero value = %v", zero.Value())
+	}
+
+	// 不修改调用方的参数
+	args := []interface{}{"a", lv}
+	resolved := resolveLazy(args)
+	if _, ok := args[1].(LazyValue); !ok || resolved[1] != 42 {
+		t.Fatalf("args = %v, resolved = %v", args, resolved)
+	}
+}
@@ -20,7 +20,18 @@
 
 package gog
 
-import "strings"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/yhyzgn/golus"
+)
 
 // Level 日志级别类型
 type Level int
@@ -37,34 +48,266 @@ const (
 	OFF                // 关闭所有日志
 )
 
+const (
+	// levelCustomBase 自定义日志级别的起始值
+	levelCustomBase Level = 100
+	// levelPriorityStep 内置日志级别之间的优先级间隔
+	levelPriorityStep = 100
+)
+
 // 日志级别名称
 var levelNames = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
 
+// LevelSpec 自定义日志级别定义
+type LevelSpec struct {
+	Name     string        // 名称，不区分大小写且不能与已有级别重复
+	Priority int           // 优先级，内置级别依次为 TRACE=100、DEBUG=200 ... FATAL=600
+	Color    *golus.Stylus // 色彩风格，为 nil 时不上色
+	Syslog   int           // 对应的 syslog 严重程度（0 ~ 7）
+}
+
+var (
+	levelMu      sync.RWMutex
+	customLevels = make(map[Level]*LevelSpec) // 自定义日志级别
+	levelsByName = make(map[string]Level)     // 大写名称到自定义日志级别
+	nextLevel    = levelCustomBase            // 下一个自定义日志级别的值
+)
+
+// RegisterLevel 注册自定义日志级别
+//
+// 优先级需介于 ALL 和 OFF 之间，如 350 表示介于 INFO 和 WARN 之间
+func RegisterLevel(spec LevelSpec) (Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(spec.Name))
+	if name == "" {
+		return OFF, errors.New("gog: level name must not be empty")
+	}
+	if spec.Priority <= ALL.Priority() || spec.Priority >= OFF.Priority() {
+		return OFF, errors.New("gog: level priority must be between ALL and OFF")
+	}
+	if spec.Syslog < 0 || spec.Syslog > 7 {
+		return OFF, errors.New("gog: syslog severity must be between 0 and 7")
+	}
+
+	levelMu.Lock()
+	defer levelMu.Unlock()
+	if _, ok := lookupLevel(name); ok {
+		return OFF, errors.New("gog: level " + name + " already exists")
+	}
+	spec.Name = name
+	level := nextLevel
+	nextLevel++
+	customLevels[level] = &spec
+	levelsByName[name] = level
+	return level, nil
+}
+
+// MustRegisterLevel 注册自定义日志级别，失败时 panic
+func MustRegisterLevel(spec LevelSpec) Level {
+	level, err := RegisterLevel(spec)
+	if err != nil {
+		panic(err)
+	}
+	return level
+}
+
+// Levels 获取所有可输出的日志级别，按优先级递增排列
+func Levels() []Level {
+	levels := []Level{TRACE, DEBUG, INFO, WARN, ERROR, FATAL}
+	levelMu.RLock()
+	for level := range customLevels {
+		levels = append(levels, level)
+	}
+	levelMu.RUnlock()
+	sort.SliceStable(levels, func(i, j int) bool {
+		return levels[i].Priority() < levels[j].Priority()
+	})
+	return levels
+}
+
+// Priority 日志级别的优先级
+func (l Level) Priority() int {
+	if l.builtin() {
+		if l == OFF {
+			return math.MaxInt32
+		}
+		return int(l) * levelPriorityStep
+	}
+	if spec := customLevel(l); spec != nil {
+		return spec.Priority
+	}
+	return 0
+}
+
+// AtLeast 判断日志级别的优先级是否不低于 min
+func (l Level) AtLeast(min Level) bool {
+	if l.builtin() && min.builtin() {
+		return l >= min
+	}
+	return l.Priority() >= min.Priority()
+}
+
+// Valid 是否为可输出的日志级别，即 TRACE ~ FATAL 或已注册的自定义级别
+func (l Level) Valid() bool {
+	if l.builtin() {
+		return l != ALL && l != OFF
+	}
+	return customLevel(l) != nil
+}
+
+// builtin 是否为内置日志级别
+func (l Level) builtin() bool {
+	return l >= ALL && l <= OFF
+}
+
+// customLevel 获取自定义日志级别的定义
+func customLevel(level Level) *LevelSpec {
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	return customLevels[level]
+}
+
+// lookupLevel 根据大写名称查找日志级别
+func lookupLevel(name string) (Level, bool) {
+	switch name {
+	case "ALL":
+		return ALL, true
+	case "OFF":
+		return OFF, true
+	}
+	for i, levelName := range levelNames {
+		if levelName == name {
+			return Level(i + 1), true
+		}
+	}
+	level, ok := levelsByName[name]
+	return level, ok
+}
+
 // GetLevelName 获取日志级别名称
 func GetLevelName(level Level) string {
 	if level > 0 && int(level) <= len(levelNames) {
 		return levelNames[level-1]
 	}
+	if spec := customLevel(level); spec != nil {
+		return spec.Name
+	}
 	return "UNKNOWN"
 }
 
-// ParseLevel 根据 name 解析 level
+// SyslogSeverity 获取日志级别对应的 syslog 严重程度（RFC 5424）
+func SyslogSeverity(level Level) int {
+	switch level {
+	case TRACE, DEBUG:
+		return 7 // debug
+	case INFO:
+		return 6 // informational
+	case WARN:
+		return 4 // warning
+	case ERROR:
+		return 3 // error
+	case FATAL:
+		return 2 // critical
+	}
+	if spec := customLevel(level); spec != nil {
+		return spec.Syslog
+	}
+	return 5 // notice
+}
+
+// ParseLevel 根据 name 解析 level，包括已注册的自定义级别
 func ParseLevel(levelName string) Level {
-	switch strings.ToUpper(levelName) {
-	case "TRACE":
-		return TRACE
-	case "DEBUG":
-		return DEBUG
-	case "INFO":
-		return INFO
-	case "WARN":
-		return WARN
-	case "ERROR":
-		return ERROR
-	case "FATAL":
-		return FATAL
-	case "OFF":
-		return OFF
+	levelMu.RLock()
+	defer levelMu.RUnlock()
+	if level, ok := lookupLevel(strings.ToUpper(levelName)); ok {
+		return level
 	}
 	return ALL
 }
+
+// 日志级别的常用别名
+var levelAliases = map[string]Level{
+	"WARNING":     WARN,
+	"ERR":         ERROR,
+	"CRIT":        FATAL,
+	"CRITICAL":    FATAL,
+	"INFORMATION": INFO,
+	"NONE":        OFF,
+}
+
+// ParseLevelStrict 根据 name 严格解析 level，无法识别时返回错误
+//
+// 不区分大小写，支持已注册的自定义级别、常用别名（warning、err、crit 等）以及数值
+func ParseLevelStrict(levelName string) (Level, error) {
+	name := strings.ToUpper(strings.TrimSpace(levelName))
+	levelMu.RLock()
+	level, ok := lookupLevel(name)
+	levelMu.RUnlock()
+	if ok {
+		return level, nil
+	}
+	if level, ok = levelAliases[name]; ok {
+		return level, nil
+	}
+	if num, err := strconv.Atoi(name); err == nil {
+		if level = Level(num); level.builtin() || level.Valid() {
+			return level, nil
+		}
+	}
+	return ALL, fmt.Errorf("gog: unknown level %q", levelName)
+}
+
+// String 日志级别名称，实现 fmt.Stringer
+func (l Level) String() string {
+	switch l {
+	case ALL:
+		return "ALL"
+	case OFF:
+		return "OFF"
+	}
+	return GetLevelName(l)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (l Level) MarshalText() ([]byte, error) {
+	if !l.builtin() && !l.Valid() {
+		return nil, fmt.Errorf("gog: unknown level %d", int(l))
+	}
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevelStrict(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler，输出级别名称
+func (l Level) MarshalJSON() ([]byte, error) {
+	text, err := l.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，支持级别名称和数值
+func (l *Level) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var num int
+		if json.Unmarshal(data, &num) != nil {
+			return err
+		}
+		name = strconv.Itoa(num)
+	}
+	return l.UnmarshalText([]byte(name))
+}
+
+// Set 实现 flag.Value
+func (l *Level) Set(value string) error {
+	return l.UnmarshalText([]byte(value))
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-21 09:40
+// version: 1.0.0
+// desc   : 日志级别
+
+package gog
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+)
+
+func TestParseLevelStrict(t *testing.T) {
+	cases := map[string]Level{
+		"warn":    WARN,
+		"Warning": WARN,
+		"err":     ERROR,
+		"CRIT":    FATAL,
+		"off":     OFF,
+		"2":       DEBUG,
+	}
+	for name, want := range cases {
+		if got, err := ParseLevelStrict(name); err != nil || got != want {
+			t.Errorf("ParseLevelStrict(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	for _, name := range []string{"", "WARNNING", "42"} {
+		if _, err := ParseLevelStrict(name); err == nil {
+			t.Errorf("ParseLevelStrict(%q) should fail", name)
+		}
+	}
+}
+
+func TestLevelMarshal(t *testing.T) {
+	var config struct {
+		Level Level `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(`{"level":"warning"}`), &config); err != nil || config.Level != WARN {
+		t.Fatalf("unmarshal = %v, %v", config.Level, err)
+	}
+	if err := json.Unmarshal([]byte(`{"level":5}`), &config); err != nil || config.Level != ERROR {
+		t.Fatalf("unmarshal number = %v, %v", config.Level, err)
+	}
+	if data, _ := json.Marshal(config); string(data) != `{"level":"ERROR"}` {
+		t.Fatalf("marshal = %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"level":"verbose"}`), &config); err == nil {
+		t.Fatal("unknown level should fail")
+	}
+
+	level := INFO
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&level, "level", "log level")
+	if err := fs.Parse([]string{"-level", "debug"}); err != nil || level != DEBUG {
+		t.Fatalf("flag = %v, %v", level, err)
+	}
+}
+
+// 测试用的自定义日志级别，只能注册一次
+var (
+	levelNotice = MustRegisterLevel(LevelSpec{Name: "notice", Priority: 350, Syslog: 5})
+	levelAudit  = MustRegisterLevel(LevelSpec{Name: "Audit", Priority: 650, Syslog: 1})
+)
+
+func TestRegisterLevel(t *testing.T) {
+	if levelNotice.Priority() != 350 || levelAudit.Priority() != 650 {
+		t.Fatalf("priority = %d, %d", levelNotice.Priority(), levelAudit.Priority())
+	}
+	if GetLevelName(levelNotice) != "NOTICE" || levelAudit.String() != "AUDIT" {
+		t.Fatalf("name = %s, %s", GetLevelName(levelNotice), levelAudit)
+	}
+	if !levelNotice.Valid() || Level(levelCustomBase+99).Valid() || ALL.Valid() || OFF.Valid() {
+		t.Fatal("unexpected Valid result")
+	}
+	if SyslogSeverity(levelNotice) != 5 || SyslogSeverity(levelAudit) != 1 {
+		t.Fatalf("syslog = %d, %d", SyslogSeverity(levelNotice), SyslogSeverity(levelAudit))
+	}
+	if level, err := ParseLevelStrict("Notice"); err != nil || level != levelNotice {
+		t.Fatalf("ParseLevelStrict = %v, %v", level, err)
+	}
+
+	var order []Level
+	for _, level := range Levels() {
+		if level.builtin() || level == levelNotice || level == levelAudit {
+			order = append(order, level)
+		}
+	}
+	want := []Level{TRACE, DEBUG, INFO, levelNotice, WARN, ERROR, FATAL, levelAudit}
+	if len(order) != len(want) {
+		t.Fatalf("Levels() = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Levels() = %v, want %v", order, want)
+		}
+	}
+
+	errs := []LevelSpec{
+		{Name: "NOTICE", Priority: 360},
+		{Name: "warn", Priority: 360},
+		{Name: " ", Priority: 360},
+		{Name: "low", Priority: 0},
+		{Name: "high", Priority: OFF.Priority()},
+		{Name: "syslog", Priority: 360, Syslog: 8},
+	}
+	for _, spec := range errs {
+		if _, err := RegisterLevel(spec); err == nil {
+			t.Errorf("RegisterLevel(%+v) should fail", spec)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegisterLevel should panic on duplicate name")
+		}
+	}()
+	MustRegisterLevel(LevelSpec{Name: "audit", Priority: 660})
+}
+
+func TestLevelAtLeast(t *testing.T) {
+	cases := []struct {
+		level, min Level
+		want       bool
+	}{
+		{INFO, INFO, true},
+		{WARN, INFO, true},
+		{DEBUG, INFO, false},
+		{levelNotice, INFO, true},
+		{levelNotice, WARN, false},
+		{INFO, levelNotice, false},
+		{WARN, levelNotice, true},
+		{levelNotice, levelNotice, true},
+		{levelAudit, FATAL, true},
+		{FATAL, levelAudit, false},
+		{levelAudit, levelNotice, true},
+		{levelNotice, ALL, true},
+		{levelAudit, OFF, false},
+	}
+	for _, c := range cases {
+		if got := c.level.AtLeast(c.min); got != c.want {
+			t.Errorf("%s.AtLeast(%s) = %v, want %v", c.level, c.min, got, c.want)
+		}
+	}
+
+	out := &bytesWriter{}
+	lf := NewLogfmtFormatter()
+	lf.Keys = LogfmtKeys{Level: "level", Message: "msg"}
+	g := NewGog(levelNotice, 0).SetConfig(&Config{Formatter: lf, Writers: []Writer{out}})
+	g.Log(INFO, "info")
+	g.Log(levelNotice, "notice")
+	g.LogF(WARN, "{}", "warn")
+	g.Log(Level(levelCustomBase+99), "invalid")
+	g.Level(WARN).Log(levelNotice, "filtered")
+	want := "level=NOTICE msg=notice\nlevel=WARN msg=warn\n"
+	if out.String() != want {
+		t.Fatalf("got %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-21 14:05
+// version: 1.0.0
+// desc   : 日志指标统计
+
+package gog
+
+import (
+	"bytes"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metrics 日志指标统计
+//
+// 按日志级别和标签（可选发生地）统计输出的日志条数，并统计每个输出器写出的字节数和错误次数，
+// 可通过 Handler 以 Prometheus 文本格式导出，或通过 Publish 发布到 expvar
+type Metrics struct {
+	mu      sync.Mutex
+	caller  bool                  // 是否按发生地统计
+	records map[metricsKey]uint64 // 日志条数
+	bytes   map[string]uint64     // 每个输出器写出的字节数
+	errors  map[string]uint64     // 每个输出器写出失败的次数
+}
+
+// metricsKey 日志条数的统计维度
+type metricsKey struct {
+	level  string
+	tag    string
+	caller string
+}
+
+// MetricsRecord 日志条数统计结果
+type MetricsRecord struct {
+	Level  string `json:"level"`
+	Tag    string `json:"tag"`
+	Caller string `json:"caller,omitempty"`
+	Count  uint64 `json:"count"`
+}
+
+// MetricsSnapshot 指标快照
+type MetricsSnapshot struct {
+	Records      []MetricsRecord   `json:"records"`
+	WriterBytes  map[string]uint64 `json:"writer_bytes"`
+	WriterErrors map[string]uint64 `json:"writer_errors"`
+}
+
+// NewMetrics 创建日志指标统计对象
+func NewMetrics() *Metrics {
+	return &Metrics{
+		records: make(map[metricsKey]uint64),
+		bytes:   make(map[string]uint64),
+		errors:  make(map[string]uint64),
+	}
+}
+
+// Caller 是否按发生地（文件:行号）统计
+//
+// 发生地会显著增加指标数量，默认关闭
+func (m *Metrics) Caller(caller bool) *Metrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.caller = caller
+	return m
+}
+
+// Reset 清空所有统计
+func (m *Metrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.records = make(map[metricsKey]uint64)
+	m.bytes = make(map[string]uint64)
+	m.errors = make(map[string]uint64)
+}
+
+// observeRecord 统计一条日志
+func (m *Metrics) observeRecord(info *LogInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := metricsKey{level: GetLevelName(info.Level), tag: info.Tag}
+	if m.caller {
+		key.caller = info.File + ":" + strconv.Itoa(info.Line)
+	}
+	m.records[key]++
+}
+
+// observeWrite 统计输出器的一次写出
+func (m *Metrics) observeWrite(w Writer, n int, err error) {
+	name := writerName(w)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n > 0 {
+		m.bytes[name] += uint64(n)
+	}
+	if err != nil {
+		m.errors[name]++
+	}
+}
+
+// Snapshot 获取指标快照，日志条数按级别、标签、发生地排列
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := MetricsSnapshot{
+		Records:      make([]MetricsRecord, 0, len(m.records)),
+		WriterBytes:  make(map[string]uint64, len(m.bytes)),
+		WriterErrors: make(map[string]uint64, len(m.errors)),
+	}
+	for key, count := range m.records {
+		snapshot.Records = append(snapshot.Records, MetricsRecord{Level: key.level, Tag: key.tag, Caller: key.caller, Count: count})
+	}
+	for name, n := range m.bytes {
+		snapshot.WriterBytes[name] = n
+	}
+	for name, n := range m.errors {
+		snapshot.WriterErrors[name] = n
+	}
+	sort.Slice(snapshot.Records, func(i, j int) bool {
+		a, b := snapshot.Records[i], snapshot.Records[j]
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		return a.Caller < b.Caller
+	})
+	return snapshot
+}
+
+// Handler 以 Prometheus 文本格式导出指标的 http 处理器
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(m.Prometheus())
+	})
+}
+
+// Prometheus 按 Prometheus 文本格式输出指标
+func (m *Metrics) Prometheus() []byte {
+	snapshot := m.Snapshot()
+	var buf bytes.Buffer
+
+	buf.WriteString("# HELP gog_records_total Number of log records emitted.\n")
+	buf.WriteString("# TYPE gog_records_total counter\n")
+	for _, record := range snapshot.Records {
+		buf.WriteString("gog_records_total{level=")
+		writePromLabel(&buf, record.Level)
+		buf.WriteString(",tag=")
+		writePromLabel(&buf, record.Tag)
+		if record.Caller != "" {
+			buf.WriteString(",caller=")
+			writePromLabel(&buf, record.Caller)
+		}
+		fmt.Fprintf(&buf, "} %d\n", record.Count)
+	}
+
+	writePromWriters(&buf, "gog_writer_bytes_total", "Number of bytes written per writer.", snapshot.WriterBytes)
+	writePromWriters(&buf, "gog_writer_errors_total", "Number of failed writes per writer.", snapshot.WriterErrors)
+	return buf.Bytes()
+}
+
+// Publish 以 name 发布到 expvar，name 重复时 expvar 会 panic
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return m.Snapshot()
+	}))
+}
+
+// writePromWriters 输出按输出器统计的指标
+func writePromWriters(buf *bytes.Buffer, metric, help string, values map[string]uint64) {
+	buf.WriteString("# HELP " + metric + " " + help + "\n")
+	buf.WriteString("# TYPE " + metric + " counter\n")
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		buf.WriteString(metric + "{writer=")
+		writePromLabel(buf, name)
+		fmt.Fprintf(buf, "} %d\n", values[name])
+	}
+}
+
+// Prometheus 标签值转义
+var promLabelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writePromLabel 输出带引号的标签值
+func writePromLabel(buf *bytes.Buffer, value string) {
+	buf.WriteByte('"')
+	buf.WriteString(promLabelReplacer.Replace(value))
+	buf.WriteByte('"')
+}
+
+// writerName 输出器名称，输出器实现了 NamedWriter 时使用其名称，否则为其类型名称
+func writerName(w Writer) string {
+	if nw, ok := w.(NamedWriter); ok {
+		if name := nw.Name(); name != "" {
+			return name
+		}
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", w), "*")
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-21 14:05
+// version: 1.0.0
+// desc   : 日志指标统计
+
+package gog
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	g := NewGog(DEBUG, 0).
+		SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{NewRingWriter(10)}}).
+		Metrics(metrics)
+	g.InfoTag("db", "query")
+	g.InfoTag("db", "query")
+	g.ErrorTag(`say "hi"`, "failed")
+	g.Trace("dropped")
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`gog_records_total{level="INFO",tag="db"} 2`,
+		`gog_records_total{level="ERROR",tag="say \"hi\""} 1`,
+		`gog_writer_bytes_total{writer="gog.RingWriter"} `,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "TRACE") {
+		t.Errorf("disabled level counted:\n%s", body)
+	}
+}
+
+// namedWriter 指定了名称的输出器
+type namedWriter struct {
+	*RingWriter
+	name string
+}
+
+func (nw *namedWriter) Name() string {
+	return nw.name
+}
+
+func TestMetricsWriterName(t *testing.T) {
+	metrics := NewMetrics()
+	dir := t.TempDir()
+	access, err := NewFileWriter(filepath.Join(dir, "access.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, err := NewFileWriter(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGog(DEBUG, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{
+		access, app,
+		&namedWriter{RingWriter: NewRingWriter(10), name: "primary"},
+		&namedWriter{RingWriter: NewRingWriter(10), name: "replica"},
+		&namedWriter{RingWriter: NewRingWriter(10)},
+	}}).Metrics(metrics)
+	defer func() { _ = g.Close() }()
+	g.Info("query")
+
+	bytes := metrics.Snapshot().WriterBytes
+	names := []string{access.Name(), app.Name(), "primary", "replica", "gog.namedWriter"}
+	if len(bytes) != len(names) {
+		t.Fatalf("writer series = %v, want %v", bytes, names)
+	}
+	for _, name := range names {
+		if bytes[name] == 0 {
+			t.Errorf("missing writer %q in %v", name, bytes)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-20 16:15
+// version: 1.0.0
+// desc   : 敏感信息脱敏
+
+package gog
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MaskStyle 脱敏方式
+type MaskStyle int
+
+// 脱敏方式
+const (
+	MaskDefault MaskStyle = iota // 使用 Redactor 的默认方式
+	MaskFull                     // 全部替换为 ******
+	MaskPartial                  // 保留首尾部分字符，其余替换为 *
+	MaskHash                     // 替换为 HMAC-SHA256 摘要的前 16 位，必须通过 Redactor.HashKey 设置密钥
+)
+
+// 默认视为敏感信息的字段名
+var defaultSensitiveFields = []string{
+	"password", "passwd", "pwd", "secret", "token", "access_token", "refresh_token",
+	"authorization", "api_key", "apikey", "cookie", "credential",
+}
+
+// RedactRule 脱敏规则
+type RedactRule struct {
+	Name       string                    // 规则名称
+	Pattern    *regexp.Regexp            // 匹配的内容
+	Group      int                       // 只对该分组的内容脱敏，0 表示整个匹配内容
+	Validate   func(matched string) bool // 进一步校验匹配的内容，为 nil 时不校验
+	Style      MaskStyle                 // 脱敏方式
+	KeepPrefix int                       // 部分脱敏时保留的前缀字符数，与 KeepSuffix 都为 0 时自动计算
+	KeepSuffix int                       // 部分脱敏时保留的后缀字符数
+}
+
+// NewRegexRule 创建正则表达式脱敏规则
+func NewRegexRule(name, expr string, style MaskStyle) (*RedactRule, error) {
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &RedactRule{Name: name, Pattern: pattern, Style: style}, nil
+}
+
+// JWTRule JWT 脱敏规则
+func JWTRule() *RedactRule {
+	return &RedactRule{
+		Name:       "jwt",
+		Pattern:    regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		KeepPrefix: 6,
+	}
+}
+
+// BearerRule Bearer 令牌脱敏规则，只对令牌部分脱敏
+func BearerRule() *RedactRule {
+	return &RedactRule{
+		Name:       "bearer",
+		Pattern:    regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9._~+/-]+=*)`),
+		Group:      1,
+		KeepPrefix: 4,
+	}
+}
+
+// CreditCardRule 银行卡号脱敏规则，通过 Luhn 算法校验
+func CreditCardRule() *RedactRule {
+	return &RedactRule{
+		Name:       "credit_card",
+		Pattern:    regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Validate:   luhnValid,
+		KeepSuffix: 4,
+	}
+}
+
+// ChinaMobileRule 中国大陆手机号脱敏规则
+func ChinaMobileRule() *RedactRule {
+	return &RedactRule{
+		Name:       "china_mobile",
+		Pattern:    regexp.MustCompile(`\b1[3-9]\d{9}\b`),
+		KeepPrefix: 3,
+		KeepSuffix: 4,
+	}
+}
+
+// ChinaIDCardRule 中国大陆居民身份证号脱敏规则，通过校验码校验
+func ChinaIDCardRule() *RedactRule {
+	return &RedactRule{
+		Name:       "china_id_card",
+		Pattern:    regexp.MustCompile(`\b\d{17}[\dXx]\b`),
+		Validate:   idCardValid,
+		KeepPrefix: 3,
+		KeepSuffix: 4,
+	}
+}
+
+// DefaultRedactRules 内置的脱敏规则
+func DefaultRedactRules() []*RedactRule {
+	// 身份证号需要在银行卡号之前匹配
+	return []*RedactRule{JWTRule(), BearerRule(), ChinaIDCardRule(), CreditCardRule(), ChinaMobileRule()}
+}
+
+// Redactor 敏感信息脱敏处理器
+//
+// 按字段名对结构化字段以及日志详情中的 key=value、"key":"value" 脱敏，
+// 再按规则对日志详情和字段值脱敏
+type Redactor struct {
+	mu        sync.RWMutex
+	style     MaskStyle           // 默认脱敏方式
+	fields    map[string]struct{} // 敏感字段名，小写
+	fieldRule *RedactRule         // 日志详情中的敏感字段规则
+	rules     []*RedactRule       // 脱敏规则
+	hashKey   []byte              // MaskHash 使用的 HMAC 密钥
+}
+
+// NewRedactor 创建脱敏处理器
+func NewRedactor(style MaskStyle) *Redactor {
+	if style == MaskDefault {
+		style = MaskFull
+	}
+	return &Redactor{
+		style:  style,
+		fields: make(map[string]struct{}),
+	}
+}
+
+// NewDefaultRedactor 创建包含默认敏感字段名和内置规则的脱敏处理器，使用部分脱敏
+func NewDefaultRedactor() *Redactor {
+	return NewRedactor(MaskPartial).Fields(defaultSensitiveFields...).AddRule(DefaultRedactRules()...)
+}
+
+// Fields 添加敏感字段名，不区分大小写
+func (r *Redactor) Fields(names ...string) *Redactor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, name := range names {
+		if name != "" {
+			r.fields[strings.ToLower(name)] = struct{}{}
+		}
+	}
+	if len(r.fields) == 0 {
+		// 空的分组会匹配所有 key=value
+		return r
+	}
+
+	quoted := make([]string, 0, len(r.fields))
+	for name := range r.fields {
+		quoted = append(quoted, regexp.QuoteMeta(name))
+	}
+	r.fieldRule = &RedactRule{
+		Name:    "field",
+		Pattern: regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)(["']?\s*[:=]\s*["']?)((?:bearer|basic|digest)\s+)?([^\s"'&,;}]+)`),
+		Group:   4,
+	}
+	return r
+}
+
+// HashKey 设置 MaskHash 使用的 HMAC 密钥
+//
+// 手机号、身份证号、银行卡号等取值范围有限，不加密钥的摘要可以被穷举还原，
+// 因此未设置密钥时 MaskHash 按 MaskFull 处理；密钥应妥善保管，同一密钥下相同内容的摘要相同，便于关联排查
+func (r *Redactor) HashKey(key []byte) *Redactor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hashKey = append([]byte{}, key...)
+	return r
+}
+
+// AddRule 添加脱敏规则
+func (r *Redactor) AddRule(rules ...*RedactRule) *Redactor {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = append(r.rules, rules...)
+	return r
+}
+
+// Redact 对日志记录脱敏
+func (r *Redactor) Redact(info *LogInfo) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info.Body = r.redactString(info.Body)
+	for i, field := range info.Fields {
+		if _, ok := r.fields[strings.ToLower(field.Key)]; ok {
+			info.Fields[i] = String(field.Key, r.mask(field.ValueString(), nil))
+			continue
+		}
+		switch field.Type {
+		case IntType, UintType, FloatType, BoolType, DurationType, TimeType:
+			// 数值类型不做规则匹配
+		default:
+			value := field.ValueString()
+			if redacted := r.redactString(value); redacted != value {
+				info.Fields[i] = String(field.Key, redacted)
+			}
+		}
+	}
+}
+
+// Levels 作为钩子时在所有级别触发
+func (r *Redactor) Levels() []Level {
+	return nil
+}
+
+// Fire 作为钩子时对日志记录脱敏
+func (r *Redactor) Fire(info *LogInfo) error {
+	r.Redact(info)
+	return nil
+}
+
+// RedactString 对字符串脱敏
+func (r *Redactor) RedactString(text string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.redactString(text)
+}
+
+func (r *Redactor) redactString(text string) string {
+	if text == "" {
+		return text
+	}
+	// 先收集所有规则匹配到的区间，区间重叠时靠前的规则优先
+	spans := make([]redactSpan, 0)
+	collect := func(rule *RedactRule) {
+		for _, match := range rule.Pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := match[2*rule.Group], match[2*rule.Group+1]
+			if start < 0 || start == end || (rule.Validate != nil && !rule.Validate(text[start:end])) {
+				continue
+			}
+			if !overlaps(spans, start, end) {
+				spans = append(spans, redactSpan{start: start, end: end, rule: rule})
+			}
+		}
+	}
+	if r.fieldRule != nil {
+		collect(r.fieldRule)
+	}
+	for _, rule := range r.rules {
+		collect(rule)
+	}
+	if len(spans) == 0 {
+		return text
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+	var sb strings.Builder
+	last := 0
+	for _, span := range spans {
+		sb.WriteString(text[last:span.start])
+		sb.WriteString(r.mask(text[span.start:span.end], span.rule))
+		last = span.end
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// redactSpan 需要脱敏的区间
+type redactSpan struct {
+	start, end int
+	rule       *RedactRule
+}
+
+// overlaps 判断区间是否与已有区间重叠
+func overlaps(spans []redactSpan, start, end int) bool {
+	for _, span := range spans {
+		if start < span.end && span.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// mask 按脱敏方式处理内容
+func (r *Redactor) mask(value string, rule *RedactRule) string {
+	style := r.style
+	if rule != nil && rule.Style != MaskDefault {
+		style = rule.Style
+	}
+	switch style {
+	case MaskHash:
+		if len(r.hashKey) == 0 {
+			break
+		}
+		mac := hmac.New(sha256.New, r.hashKey)
+		mac.Write([]byte(value))
+		return "hmac:" + hex.EncodeToString(mac.Sum(nil)[:8])
+	case MaskPartial:
+		prefix, suffix := 0, 0
+		if rule != nil {
+			prefix, suffix = rule.KeepPrefix, rule.KeepSuffix
+		}
+		return maskPartial(value, prefix, suffix)
+	}
+	return "******"
+}
+
+// maskPartial 保留首尾部分字符，其余替换为 *
+//
+// 未指定保留字符数时，首尾各保留不超过四分之一（最多 4 个）字符
+func maskPartial(value string, prefix, suffix int) string {
+	runes := []rune(value)
+	n := len(runes)
+	if prefix == 0 && suffix == 0 {
+		prefix = n / 4
+		if prefix > 4 {
+			prefix = 4
+		}
+		suffix = prefix
+	}
+	if prefix+suffix >= n {
+		return strings.Repeat("*", n)
+	}
+	return string(runes[:prefix]) + strings.Repeat("*", n-prefix-suffix) + string(runes[n-suffix:])
+}
+
+// luhnValid 使用 Luhn 算法校验银行卡号
+func luhnValid(number string) bool {
+	sum, count, double := 0, 0, false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		count++
+		double = !double
+	}
+	return count >= 13 && count <= 19 && sum%10 == 0
+}
+
+// idCardValid 校验身份证号的校验码（GB 11643-1999）
+func idCardValid(number string) bool {
+	weights := [17]int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	sum := 0
+	for i, weight := range weights {
+		sum += int(number[i]-'0') * weight
+	}
+	check := "10X98765432"[sum%11]
+	last := number[17]
+	if last == 'x' {
+		last = 'X'
+	}
+	return last == check
+}
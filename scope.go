@@ -0,0 +1,360 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-25 10:40
+// version: 1.0.0
+// desc   : 按作用域缓冲低级别日志，出错时再输出
+
+package gog
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// ScopeCapacityDefault 作用域默认最多缓冲的日志条数
+	ScopeCapacityDefault = 1000
+)
+
+// Scope 日志作用域（fingers crossed）
+//
+// 作用域内低于日志级别的日志先缓冲起来，一旦记录了 >= 激活级别的日志，就按顺序输出缓冲的日志，
+// 之后作用域内的日志都直接输出；作用域结束时仍未激活则丢弃缓冲的日志。
+// 通常每个请求或任务创建一个作用域，通过 context 传递：
+//
+//	scope := gog.NewScope(gog.ERROR)
+//	defer scope.End()
+//	ctx = gog.ContextWithScope(ctx, scope)
+//	...
+//	gog.ScopeFromContext(ctx).Debug("detail")
+//
+// 作用域只提供打印日志的方法，不能修改或关闭父日志处理器的输出器
+type Scope struct {
+	gog    *Gog
+	buffer *scopeBuffer
+}
+
+// scopeBuffer 作用域的日志缓冲，由作用域派生的日志处理器共用
+type scopeBuffer struct {
+	mu          sync.Mutex
+	activation  Level      // 激活级别
+	bufferLevel Level      // 缓冲的最低级别
+	capacity    int        // 最多缓冲的条数，超出时丢弃最早的日志
+	records     []*LogInfo // 缓冲的日志
+	activated   bool       // 是否已激活
+	ended       bool       // 是否已结束
+}
+
+// scopeKey 作用域在 context 中的键
+type scopeKey struct{}
+
+// Scope 从当前日志处理器派生一个作用域，记录 >= activation 的日志时激活
+//
+// 默认缓冲所有低于日志级别的日志，最多 ScopeCapacityDefault 条
+func (g *Gog) Scope(activation Level) *Scope {
+	buffer := &scopeBuffer{
+		activation:  activation,
+		bufferLevel: ALL,
+		capacity:    ScopeCapacityDefault,
+	}
+	derived := g.clone()
+	derived.scope = buffer
+	// 作用域的方法多封装了一层
+	derived.callSkip++
+	derived.wrapperSkip = 1
+	return &Scope{gog: derived, buffer: buffer}
+}
+
+// NewScope 从默认日志对象派生一个作用域，直接调用作用域的方法打印日志
+func NewScope(activation Level) *Scope {
+	return gog.Scope(activation)
+}
+
+// ContextWithScope 将作用域保存到 context 中
+func ContextWithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// ScopeFromContext 从 context 中获取作用域，不存在时返回 nil
+func ScopeFromContext(ctx context.Context) *Scope {
+	scope, _ := ctx.Value(scopeKey{}).(*Scope)
+	return scope
+}
+
+// BufferLevel 设置缓冲的最低级别，更低级别的日志直接丢弃
+func (s *Scope) BufferLevel(level Level) *Scope {
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+	s.buffer.bufferLevel = level
+	return s
+}
+
+// Capacity 设置最多缓冲的日志条数，超出时丢弃最早的日志
+func (s *Scope) Capacity(capacity int) *Scope {
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+	s.buffer.capacity = capacity
+	return s
+}
+
+// Activated 作用域是否已激活
+func (s *Scope) Activated() bool {
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+	return s.buffer.activated
+}
+
+// Len 当前缓冲的日志条数
+func (s *Scope) Len() int {
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+	return len(s.buffer.records)
+}
+
+// Flush 立即按顺序输出缓冲的日志，不改变激活状态
+func (s *Scope) Flush() {
+	s.buffer.mu.Lock()
+	records := s.buffer.records
+	s.buffer.records = nil
+	s.buffer.mu.Unlock()
+	for _, info := range records {
+		s.gog.dispatch(info)
+	}
+}
+
+// End 结束作用域，丢弃缓冲的日志，之后低于日志级别的日志不再缓冲
+func (s *Scope) End() {
+	s.buffer.mu.Lock()
+	defer s.buffer.mu.Unlock()
+	s.buffer.ended = true
+	s.buffer.records = nil
+}
+
+// Enabled 指定级别的日志是否会被输出或缓冲
+func (s *Scope) Enabled(lvl Level) bool {
+	return s.gog.Enabled(lvl)
+}
+
+// EnabledTag 指定标签和级别的日志是否会被输出或缓冲
+func (s *Scope) EnabledTag(tag string, lvl Level) bool {
+	return s.gog.EnabledTag(tag, lvl)
+}
+
+// IsTraceEnabled TRACE 级别日志是否会被输出或缓冲
+func (s *Scope) IsTraceEnabled() bool {
+	return s.gog.IsTraceEnabled()
+}
+
+// IsDebugEnabled DEBUG 级别日志是否会被输出或缓冲
+func (s *Scope) IsDebugEnabled() bool {
+	return s.gog.IsDebugEnabled()
+}
+
+// IsInfoEnabled INFO 级别日志是否会被输出或缓冲
+func (s *Scope) IsInfoEnabled() bool {
+	return s.gog.IsInfoEnabled()
+}
+
+// IsWarnEnabled WARN 级别日志是否会被输出或缓冲
+func (s *Scope) IsWarnEnabled() bool {
+	return s.gog.IsWarnEnabled()
+}
+
+// IsErrorEnabled ERROR 级别日志是否会被输出或缓冲
+func (s *Scope) IsErrorEnabled() bool {
+	return s.gog.IsErrorEnabled()
+}
+
+// Trace 追踪打印
+func (s *Scope) Trace(body ...interface{}) {
+	s.gog.Trace(body...)
+}
+
+// TraceTag 追踪打印
+func (s *Scope) TraceTag(tag string, body ...interface{}) {
+	s.gog.TraceTag(tag, body...)
+}
+
+// TraceF 追踪打印
+func (s *Scope) TraceF(format string, args ...interface{}) {
+	s.gog.TraceF(format, args...)
+}
+
+// TraceTagF 追踪打印
+func (s *Scope) TraceTagF(tag string, format string, args ...interface{}) {
+	s.gog.TraceTagF(tag, format, args...)
+}
+
+// Debug 调试打印
+func (s *Scope) Debug(body ...interface{}) {
+	s.gog.Debug(body...)
+}
+
+// DebugTag 调试打印
+func (s *Scope) DebugTag(tag string, body ...interface{}) {
+	s.gog.DebugTag(tag, body...)
+}
+
+// DebugF 调试打印
+func (s *Scope) DebugF(format string, args ...interface{}) {
+	s.gog.DebugF(format, args...)
+}
+
+// DebugTagF 调试打印
+func (s *Scope) DebugTagF(tag string, format string, args ...interface{}) {
+	s.gog.DebugTagF(tag, format, args...)
+}
+
+// Info 普通信息打印
+func (s *Scope) Info(body ...interface{}) {
+	s.gog.Info(body...)
+}
+
+// InfoTag 普通信息打印
+func (s *Scope) InfoTag(tag string, body ...interface{}) {
+	s.gog.InfoTag(tag, body...)
+}
+
+// InfoF 普通信息打印
+func (s *Scope) InfoF(format string, args ...interface{}) {
+	s.gog.InfoF(format, args...)
+}
+
+// InfoTagF 普通信息打印
+func (s *Scope) InfoTagF(tag string, format string, args ...interface{}) {
+	s.gog.InfoTagF(tag, format, args...)
+}
+
+// Warn 警告打印
+func (s *Scope) Warn(body ...interface{}) {
+	s.gog.Warn(body...)
+}
+
+// WarnTag 警告打印
+func (s *Scope) WarnTag(tag string, body ...interface{}) {
+	s.gog.WarnTag(tag, body...)
+}
+
+// WarnF 警告打印
+func (s *Scope) WarnF(format string, args ...interface{}) {
+	s.gog.WarnF(format, args...)
+}
+
+// WarnTagF 警告打印
+func (s *Scope) WarnTagF(tag string, format string, args ...interface{}) {
+	s.gog.WarnTagF(tag, format, args...)
+}
+
+// Error 错误打印
+func (s *Scope) Error(body ...interface{}) {
+	s.gog.Error(body...)
+}
+
+// ErrorTag 错误打印
+func (s *Scope) ErrorTag(tag string, body ...interface{}) {
+	s.gog.ErrorTag(tag, body...)
+}
+
+// ErrorF 错误打印
+func (s *Scope) ErrorF(format string, args ...interface{}) {
+	s.gog.ErrorF(format, args...)
+}
+
+// ErrorTagF 错误打印
+func (s *Scope) ErrorTagF(tag string, format string, args ...interface{}) {
+	s.gog.ErrorTagF(tag, format, args...)
+}
+
+// Fatal 错误打印，并结束进程
+func (s *Scope) Fatal(body ...interface{}) {
+	s.gog.Fatal(body...)
+}
+
+// FatalTag 错误打印，并结束进程
+func (s *Scope) FatalTag(tag string, body ...interface{}) {
+	s.gog.FatalTag(tag, body...)
+}
+
+// FatalF 错误打印，并结束进程
+func (s *Scope) FatalF(format string, args ...interface{}) {
+	s.gog.FatalF(format, args...)
+}
+
+// FatalTagF 错误打印，并结束进程
+func (s *Scope) FatalTagF(tag string, format string, args ...interface{}) {
+	s.gog.FatalTagF(tag, format, args...)
+}
+
+// Log 适配器，支持自定义日志级别
+func (s *Scope) Log(level Level, body ...interface{}) {
+	s.gog.Log(level, body...)
+}
+
+// LogF 适配器，支持自定义日志级别
+func (s *Scope) LogF(level Level, format string, args ...interface{}) {
+	s.gog.LogF(level, format, args...)
+}
+
+// accepts 是否缓冲该级别的日志
+func (sb *scopeBuffer) accepts(lvl Level) bool {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return !sb.ended && lvl.AtLeast(sb.bufferLevel)
+}
+
+// handle 处理作用域内的日志，返回 true 表示已缓冲或丢弃，否则由调用方直接输出
+//
+// below 表示日志低于日志级别；激活时先按顺序输出缓冲的日志
+func (sb *scopeBuffer) handle(g *Gog, info *LogInfo, below bool) bool {
+	sb.mu.Lock()
+	if below {
+		if sb.activated {
+			sb.mu.Unlock()
+			return false
+		}
+		// accepts 之后作用域可能已结束或调整了缓冲级别，需要在同一次加锁中重新判断
+		if sb.ended || !info.Level.AtLeast(sb.bufferLevel) {
+			sb.mu.Unlock()
+			return true
+		}
+		if sb.capacity > 0 && len(sb.records) >= sb.capacity {
+			// 整体前移并清空尾部，避免底层数组继续引用被丢弃的日志
+			n := copy(sb.records, sb.records[len(sb.records)-sb.capacity+1:])
+			for i := n; i < len(sb.records); i++ {
+				sb.records[i] = nil
+			}
+			sb.records = sb.records[:n]
+		}
+		sb.records = append(sb.records, info)
+		sb.mu.Unlock()
+		return true
+	}
+
+	if sb.activated || sb.ended || !info.Level.AtLeast(sb.activation) {
+		sb.mu.Unlock()
+		return false
+	}
+	sb.activated = true
+	records := sb.records
+	sb.records = nil
+	sb.mu.Unlock()
+
+	for _, record := range records {
+		g.dispatch(record)
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-25 10:40
+// version: 1.0.0
+// desc   : 按作用域缓冲低级别日志，出错时再输出
+
+package gog
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	rw := NewRingWriter(20)
+	g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{rw}})
+	bodies := func() []string {
+		var res []string
+		for _, info := range rw.Records() {
+			res = append(res, info.Body)
+		}
+		rw.Reset()
+		return res
+	}
+	assert := func(got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("records = %v, want %v", got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("records = %v, want %v", got, want)
+			}
+		}
+	}
+
+	scope := g.Scope(ERROR)
+	ctx := ContextWithScope(context.Background(), scope)
+	scope.Debug("d1")
+	scope.Info("i1")
+	ScopeFromContext(ctx).Trace("t1")
+	if !scope.IsDebugEnabled() || g.IsDebugEnabled() {
+		t.Fatal("scope should enable buffered levels only")
+	}
+	assert(bodies(), "i1")
+	scope.Error("boom")
+	scope.Debug("after")
+	records := rw.Records()
+	if filepath.Base(records[1].File) != "scope_test.go" {
+		t.Fatalf("buffered caller = %s", records[1].File)
+	}
+	assert(bodies(), "d1", "t1", "boom", "after")
+
+	scope = g.Scope(ERROR).BufferLevel(DEBUG).Capacity(2)
+	scope.Trace("dropped")
+	scope.Debug("d1")
+	scope.Debug("d2")
+	scope.Debug("d3")
+	scope.Warn("w1")
+	scope.Error("boom")
+	assert(bodies(), "w1", "d2", "d3", "boom")
+
+	scope = g.Scope(ERROR)
+	scope.Debug("discarded")
+	scope.End()
+	scope.Debug("ended")
+	scope.Info("i2")
+	assert(bodies(), "i2")
+}
+
+func TestScopeBufferHandle(t *testing.T) {
+	g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{NewRingWriter(1)}})
+	sb := &scopeBuffer{activation: ERROR, bufferLevel: DEBUG, capacity: 2}
+	infos := []*LogInfo{{Level: DEBUG, Body: "d1"}, {Level: DEBUG, Body: "d2"}, {Level: DEBUG, Body: "d3"}}
+	for _, info := range infos {
+		sb.handle(g, info, true)
+	}
+	if len(sb.records) != 2 || sb.records[0] != infos[1] || sb.records[1] != infos[2] {
+		t.Fatalf("records = %v", sb.records)
+	}
+	// 被丢弃的日志不能再被底层数组引用
+	for _, info := range sb.records[:cap(sb.records)] {
+		if info == infos[0] {
+			t.Fatal("dropped record still referenced")
+		}
+	}
+
+	// 低于缓冲级别或已结束时丢弃，不交给调用方输出
+	if !sb.handle(g, &LogInfo{Level: TRACE}, true) || len(sb.records) != 2 {
+		t.Fatalf("below buffer level: records = %d", len(sb.records))
+	}
+	sb.ended, sb.records = true, nil
+	if !sb.handle(g, &LogInfo{Level: DEBUG}, true) || len(sb.records) != 0 {
+		t.Fatalf("ended: records = %d", len(sb.records))
+	}
+}
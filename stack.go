@@ -0,0 +1,73 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 9:40
+// version: 1.0.0
+// desc   : 调用栈
+
+package gog
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yhyzgn/gog/util"
+)
+
+const (
+	// StackDepth 记录调用栈的最大层数
+	StackDepth = 32
+)
+
+// StackFrame 调用栈帧
+type StackFrame struct {
+	Func string `json:"func"` // 完整函数名
+	File string `json:"file"` // 完整文件路径
+	Line int    `json:"line"` // 行号
+}
+
+// captureStack 获取调用栈，skip 的含义与 runtime.Caller 一致
+func captureStack(skip int) []StackFrame {
+	frames := util.Stack(skip+1, skipCallerFrame, StackDepth)
+	stack := make([]StackFrame, 0, len(frames))
+	for _, frame := range frames {
+		stack = append(stack, StackFrame{
+			Func: frame.Function,
+			File: frame.File,
+			Line: frame.Line,
+		})
+	}
+	return stack
+}
+
+// stackString 调用栈的文本形式，与 panic 输出的格式一致
+func stackString(stack []StackFrame, indent string) string {
+	var sb strings.Builder
+	for i, frame := range stack {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(indent)
+		sb.WriteString(frame.Func)
+		sb.WriteString("\n")
+		sb.WriteString(indent)
+		sb.WriteString("\t")
+		sb.WriteString(frame.File)
+		sb.WriteString(":")
+		sb.WriteString(strconv.Itoa(frame.Line))
+	}
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 9:40
+// version: 1.0.0
+// desc   : 调用栈测试
+
+package gog
+
+import (
+	"testing"
+)
+
+// stackOuter 调用 stackInner，用于检查调用栈的顺序
+func stackOuter(g *Gog) (int, int) {
+	line := nextLine()
+	inner := stackInner(g)
+	return line, inner
+}
+
+func stackInner(g *Gog) int {
+	line := nextLine()
+	g.Error("inner")
+	return line
+}
+
+// stackRecursive 递归 depth 层后输出日志
+func stackRecursive(g *Gog, depth int) {
+	if depth > 0 {
+		stackRecursive(g, depth-1)
+		return
+	}
+	g.Error("deep")
+}
+
+func newStackTestGog() (*Gog, *RingWriter) {
+	rw := NewRingWriter(8)
+	g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{rw}}).StackLevel(ERROR)
+	return g, rw
+}
+
+func TestStackSkip(t *testing.T) {
+	g, rw := newStackTestGog()
+	g.Warn("no stack")
+	outer, inner := stackOuter(g)
+	skipLine := nextLine()
+	logSkip(g.StackLevel(INFO), "skip 1")
+
+	records := rw.Records()
+	if len(records[0].Stack) != 0 {
+		t.Fatalf("WARN recorded stack: %v", records[0].Stack)
+	}
+
+	stack := records[1].Stack
+	if len(stack) < 3 {
+		t.Fatalf("stack too short: %v", stack)
+	}
+	want := []struct {
+		fn   string
+		line int
+	}{
+		{"github.com/yhyzgn/gog.stackInner", inner},
+		{"github.com/yhyzgn/gog.stackOuter", outer},
+	}
+	for i, w := range want {
+		if stack[i].Func != w.fn || stack[i].Line != w.line {
+			t.Errorf("frame %d = %s:%d, want %s:%d", i, stack[i].Func, stack[i].Line, w.fn, w.line)
+		}
+	}
+	if stack[2].Func != "github.com/yhyzgn/gog.TestStackSkip" {
+		t.Errorf("frame 2 = %s, want TestStackSkip", stack[2].Func)
+	}
+	// 栈顶与调用源一致
+	if records[1].Line != stack[0].Line {
+		t.Errorf("caller line %d, stack top line %d", records[1].Line, stack[0].Line)
+	}
+
+	// 跳过封装函数
+	stack = records[2].Stack
+	if len(stack) == 0 || stack[0].Func != "github.com/yhyzgn/gog.TestStackSkip" || stack[0].Line != skipLine {
+		t.Errorf("wrapper stack top = %v, want TestStackSkip:%d", stack, skipLine)
+	}
+}
+
+func TestStackDepth(t *testing.T) {
+	g, rw := newStackTestGog()
+	stackRecursive(g, StackDepth*2)
+	stack := rw.Records()[0].Stack
+	if len(stack) != StackDepth {
+		t.Fatalf("stack depth = %d, want %d", len(stack), StackDepth)
+	}
+	for _, frame := range stack {
+		if frame.Func != "github.com/yhyzgn/gog.stackRecursive" {
+			t.Fatalf("unexpected frame %s", frame.Func)
+		}
+	}
+}
+
+func TestStackString(t *testing.T) {
+	stack := []StackFrame{{Func: "main.a", File: "/src/a.go", Line: 1}, {Func: "main.main", File: "/src/main.go", Line: 9}}
+	want := "\tmain.a\n\t\t/src/a.go:1\n\tmain.main\n\t\t/src/main.go:9"
+	if got := stackString(stack, "\t"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
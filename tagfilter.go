@@ -0,0 +1,182 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-25 16:00
+// version: 1.0.0
+// desc   : 按标签过滤日志
+
+package gog
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// EnvTagFilter 标签过滤规则的环境变量，创建日志处理器时读取
+	EnvTagFilter = "GOG_TAGS"
+	// tagCacheSize 最多缓存的标签数
+	tagCacheSize = 4096
+)
+
+// TagFilter 按标签设置最低日志级别
+//
+// 规则格式为逗号分隔的 "标签:级别"，如 "*:INFO,db:DEBUG,http.access:WARN,noisy:OFF"：
+//   - "*" 为默认规则，也作用于没有标签的日志
+//   - "db" 匹配标签 db 及其子标签，如 db.pool
+//   - "http*" 匹配所有以 http 开头的标签
+//
+// 多条规则匹配时使用最具体的一条，匹配到规则时以规则的级别代替日志处理器的日志级别
+type TagFilter struct {
+	rules  []tagRule // 按匹配优先级排列
+	spec   string    // 规范化后的规则
+	cache  sync.Map  // 标签到匹配结果的缓存
+	cached int32     // 已缓存的标签数
+}
+
+// tagRule 单条标签规则
+type tagRule struct {
+	pattern  string // 标签或前缀
+	wildcard bool   // 是否为前缀匹配
+	level    Level
+}
+
+// tagMatch 标签的匹配结果
+type tagMatch struct {
+	level Level
+	ok    bool
+}
+
+// ParseTagFilter 解析标签过滤规则
+func ParseTagFilter(spec string) (*TagFilter, error) {
+	filter := &TagFilter{}
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		idx := strings.LastIndexByte(item, ':')
+		if idx < 0 {
+			return nil, errors.New("gog: invalid tag filter " + item + ", want tag:LEVEL")
+		}
+		pattern := strings.TrimSpace(item[:idx])
+		level, err := ParseLevelStrict(item[idx+1:])
+		if err != nil {
+			return nil, err
+		}
+		if pattern == "" || strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+			return nil, errors.New("gog: invalid tag pattern " + pattern)
+		}
+		if seen[pattern] {
+			return nil, errors.New("gog: duplicate tag pattern " + pattern)
+		}
+		seen[pattern] = true
+
+		filter.rules = append(filter.rules, tagRule{
+			pattern:  strings.TrimSuffix(pattern, "*"),
+			wildcard: strings.HasSuffix(pattern, "*"),
+			level:    level,
+		})
+	}
+
+	// 越长越具体，同样长度时精确匹配优先
+	sort.SliceStable(filter.rules, func(i, j int) bool {
+		a, b := filter.rules[i], filter.rules[j]
+		if len(a.pattern) != len(b.pattern) {
+			return len(a.pattern) > len(b.pattern)
+		}
+		return !a.wildcard && b.wildcard
+	})
+	items := make([]string, 0, len(filter.rules))
+	for _, rule := range filter.rules {
+		items = append(items, rule.String())
+	}
+	filter.spec = strings.Join(items, ",")
+	return filter, nil
+}
+
+// MustParseTagFilter 解析标签过滤规则，失败时 panic
+func MustParseTagFilter(spec string) *TagFilter {
+	filter, err := ParseTagFilter(spec)
+	if err != nil {
+		panic(err)
+	}
+	return filter
+}
+
+// Level 获取标签的最低日志级别，没有匹配的规则时返回 false
+func (f *TagFilter) Level(tag string) (Level, bool) {
+	if value, ok := f.cache.Load(tag); ok {
+		match := value.(tagMatch)
+		return match.level, match.ok
+	}
+
+	var match tagMatch
+	for _, rule := range f.rules {
+		if rule.matches(tag) {
+			match = tagMatch{level: rule.level, ok: true}
+			break
+		}
+	}
+	if atomic.LoadInt32(&f.cached) < tagCacheSize {
+		if _, loaded := f.cache.LoadOrStore(tag, match); !loaded {
+			atomic.AddInt32(&f.cached, 1)
+		}
+	}
+	return match.level, match.ok
+}
+
+// String 规范化后的规则
+func (f *TagFilter) String() string {
+	return f.spec
+}
+
+// matches 标签是否匹配规则
+func (r tagRule) matches(tag string) bool {
+	if r.wildcard {
+		return strings.HasPrefix(tag, r.pattern)
+	}
+	return tag == r.pattern || strings.HasPrefix(tag, r.pattern) && tag[len(r.pattern)] == '.'
+}
+
+// String 规则文本
+func (r tagRule) String() string {
+	pattern := r.pattern
+	if r.wildcard {
+		pattern += "*"
+	}
+	return pattern + ":" + r.level.String()
+}
+
+// tagFilterHolder 日志处理器的标签过滤规则，支持运行时替换
+type tagFilterHolder struct {
+	value atomic.Value
+}
+
+// load 获取标签过滤规则，未设置时返回 nil
+func (h *tagFilterHolder) load() *TagFilter {
+	filter, _ := h.value.Load().(*TagFilter)
+	return filter
+}
+
+// store 替换标签过滤规则
+func (h *tagFilterHolder) store(filter *TagFilter) {
+	h.value.Store(filter)
+}
@@ -0,0 +1,91 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-25 16:00
+// version: 1.0.0
+// desc   : 按标签过滤日志
+
+package gog
+
+import "testing"
+
+func TestTagFilter(t *testing.T) {
+	filter, err := ParseTagFilter(" *:INFO, db:DEBUG ,http.access:WARN,noisy:OFF,rpc*:ERROR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]Level{
+		"":            INFO,
+		"web":         INFO,
+		"db":          DEBUG,
+		"db.pool":     DEBUG,
+		"dbx":         INFO,
+		"http":        INFO,
+		"http.access": WARN,
+		"noisy":       OFF,
+		"rpc":         ERROR,
+		"rpcx.client": ERROR,
+	}
+	for tag, want := range cases {
+		if got, ok := filter.Level(tag); !ok || got != want {
+			t.Errorf("Level(%q) = %v, %v, want %v", tag, got, ok, want)
+		}
+	}
+	if filter.String() != "http.access:WARN,noisy:OFF,rpc*:ERROR,db:DEBUG,*:INFO" {
+		t.Errorf("String() = %q", filter.String())
+	}
+	if _, ok := MustParseTagFilter("db:DEBUG").Level("web"); ok {
+		t.Error("unmatched tag without default should not match")
+	}
+	for _, spec := range []string{"db", "db:VERBOSE", "d*b:INFO", "db:INFO,db:WARN"} {
+		if _, err = ParseTagFilter(spec); err == nil {
+			t.Errorf("ParseTagFilter(%q) should fail", spec)
+		}
+	}
+
+	t.Setenv(EnvTagFilter, "*:WARN,db:DEBUG")
+	rw := NewRingWriter(10)
+	g := NewGog(INFO, 0).SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{rw}})
+	g.Info("dropped")
+	g.DebugTag("db", "query")
+	g.WarnTag("web", "slow")
+	if err = g.SetTagFilter("noisy:OFF"); err != nil {
+		t.Fatal(err)
+	}
+	g.Info("kept")
+	g.ErrorTag("noisy", "dropped")
+	records := rw.Records()
+	if len(records) != 3 || records[0].Body != "query" || records[1].Body != "slow" || records[2].Body != "kept" {
+		t.Fatalf("records = %v", records)
+	}
+	if g.EnabledTag("noisy", FATAL) || !g.EnabledTag("db", INFO) {
+		t.Fatal("unexpected EnabledTag result")
+	}
+
+	// 作用域内被关闭的标签也不缓冲，激活后不会输出
+	rw.Reset()
+	scope := g.Scope(ERROR)
+	scope.DebugTag("noisy", "dropped")
+	scope.Debug("buffered")
+	if scope.EnabledTag("noisy", DEBUG) || scope.Len() != 1 {
+		t.Fatalf("scope buffered %d records", scope.Len())
+	}
+	scope.Error("boom")
+	records = rw.Records()
+	if len(records) != 2 || records[0].Body != "buffered" || records[1].Body != "boom" {
+		t.Fatalf("records = %v", records)
+	}
+}
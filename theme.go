@@ -0,0 +1,137 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 14:25
+// version: 1.0.0
+// desc   : 色彩主题
+
+package gog
+
+import "github.com/yhyzgn/golus"
+
+// ColorScope 上色范围
+type ColorScope int
+
+const (
+	ColorLine      ColorScope = iota // 整行使用级别颜色
+	ColorSegment                     // 按主题分别为各部分上色
+	ColorLevelOnly                   // 只为级别标识上色
+)
+
+// Theme 色彩主题，样式为 nil 表示该部分不上色
+type Theme struct {
+	Time       *golus.Stylus           // 时间
+	Levels     map[Level]*golus.Stylus // 各级别标识，未设置的级别使用 Colorful(level)
+	File       *golus.Stylus           // 文件及行号
+	Func       *golus.Stylus           // 函数
+	Tag        *golus.Stylus           // 标签
+	Body       *golus.Stylus           // 日志详情
+	FieldKey   *golus.Stylus           // 字段名
+	FieldValue *golus.Stylus           // 字段值
+}
+
+// 未指定主题时使用的默认主题
+var defaultTheme = NewDefaultTheme()
+
+// NewDefaultTheme 默认主题，级别颜色与 Colorful 保持一致
+func NewDefaultTheme() *Theme {
+	return &Theme{
+		Time:     golus.New().FontColor(golus.FontBlue),
+		File:     golus.New().FontColor(golus.FontCyan),
+		Func:     golus.New().FontColor(golus.FontMagenta),
+		Tag:      golus.New().FontColor(golus.FontYellow),
+		FieldKey: golus.New().FontColor(golus.FontCyan),
+	}
+}
+
+// NewDarkTheme 适用于深色背景终端的主题
+func NewDarkTheme() *Theme {
+	return &Theme{
+		Time: golus.New().FontColor(golus.FontWhite),
+		Levels: map[Level]*golus.Stylus{
+			TRACE: golus.New().FontColor(golus.FontMagenta),
+			DEBUG: golus.New().FontColor(golus.FontCyan),
+			INFO:  golus.New().FontColor(golus.FontGreen).FontStyle(golus.StyleBold),
+			WARN:  golus.New().FontColor(golus.FontYellow).FontStyle(golus.StyleBold),
+			ERROR: golus.New().FontColor(golus.FontRed).FontStyle(golus.StyleBold),
+			FATAL: golus.New().FontColor(golus.FontWhite).BackColor(golus.BackRed).FontStyle(golus.StyleBold),
+		},
+		File:       golus.New().FontColor(golus.FontCyan),
+		Func:       golus.New().FontColor(golus.FontMagenta),
+		Tag:        golus.New().FontColor(golus.FontYellow).FontStyle(golus.StyleBold),
+		Body:       golus.New().FontColor(golus.FontWhite),
+		FieldKey:   golus.New().FontColor(golus.FontCyan),
+		FieldValue: golus.New().FontColor(golus.FontWhite),
+	}
+}
+
+// NewLightTheme 适用于浅色背景终端的主题
+func NewLightTheme() *Theme {
+	return &Theme{
+		Time: golus.New().FontColor(golus.FontBlack),
+		Levels: map[Level]*golus.Stylus{
+			TRACE: golus.New().FontColor(golus.FontMagenta),
+			DEBUG: golus.New().FontColor(golus.FontBlue),
+			INFO:  golus.New().FontColor(golus.FontGreen),
+			WARN:  golus.New().FontColor(golus.FontYellow).FontStyle(golus.StyleBold),
+			ERROR: golus.New().FontColor(golus.FontRed),
+			FATAL: golus.New().FontColor(golus.FontRed).FontStyle(golus.StyleBold, golus.StyleUnderLine),
+		},
+		File:       golus.New().FontColor(golus.FontBlue),
+		Func:       golus.New().FontColor(golus.FontMagenta),
+		Tag:        golus.New().FontColor(golus.FontBlue).FontStyle(golus.StyleBold),
+		Body:       golus.New().FontColor(golus.FontBlack),
+		FieldKey:   golus.New().FontColor(golus.FontBlue),
+		FieldValue: golus.New().FontColor(golus.FontBlack),
+	}
+}
+
+// NewMonochromeBoldTheme 不使用颜色，仅以粗体突出级别、标签和字段名
+func NewMonochromeBoldTheme() *Theme {
+	bold := func() *golus.Stylus {
+		return golus.New().FontStyle(golus.StyleBold)
+	}
+	return &Theme{
+		Levels: map[Level]*golus.Stylus{
+			TRACE: golus.New(),
+			DEBUG: golus.New(),
+			INFO:  bold(),
+			WARN:  bold(),
+			ERROR: bold(),
+			FATAL: golus.New().FontStyle(golus.StyleBold, golus.StyleReverse),
+		},
+		Tag:      bold(),
+		FieldKey: bold(),
+	}
+}
+
+// Level 获取级别标识的样式
+func (t *Theme) Level(lvl Level) *golus.Stylus {
+	if t != nil && t.Levels != nil {
+		if stylus, ok := t.Levels[lvl]; ok {
+			return stylus
+		}
+	}
+	return Colorful(lvl)
+}
+
+// paint 使用样式渲染文本，样式为 nil 时原样返回
+func paint(stylus *golus.Stylus, text string) string {
+	if stylus == nil || text == "" {
+		return text
+	}
+	return stylus.Apply(text)
+}
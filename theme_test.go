@@ -0,0 +1,133 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 14:25
+// version: 1.0.0
+// desc   : 色彩主题测试
+
+package gog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yhyzgn/gog/util"
+	"github.com/yhyzgn/golus"
+)
+
+func newThemeTestInfo() *LogInfo {
+	return &LogInfo{
+		Time:      time.Date(2026, 10, 12, 14, 40, 0, 0, time.UTC),
+		Level:     INFO,
+		Tag:       "db",
+		File:      "theme_test.go",
+		Line:      42,
+		Func:      "TestTheme",
+		Body:      "query",
+		Fields:    []Field{String("sql", "select 1")},
+		ShortFile: true,
+	}
+}
+
+func formatTheme(t *testing.T, ftr *NormalFormatter, colorful bool) string {
+	t.Helper()
+	data, err := ftr.FormatColor(INFO, "INFO", newThemeTestInfo(), colorful)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestThemeScopes(t *testing.T) {
+	plain := formatTheme(t, NewNormalFormatter(), true)
+	if strings.Contains(plain, "\x1b[") {
+		t.Fatalf("plain formatter is colourful: %q", plain)
+	}
+
+	// 整行上色
+	ftr := NewNormalColorfulFormatter()
+	want := Colorful(INFO).Apply(strings.TrimSuffix(plain, "\n")) + "\n"
+	if got := formatTheme(t, ftr, true); got != want {
+		t.Fatalf("line scope:\n got %q\nwant %q", got, want)
+	}
+	// 输出目标不支持颜色时不上色
+	if got := formatTheme(t, ftr, false); got != plain {
+		t.Fatalf("uncoloured:\n got %q\nwant %q", got, plain)
+	}
+
+	// 只为级别标识上色
+	ftr.Scope = ColorLevelOnly
+	want = strings.Replace(plain, "INFO", Colorful(INFO).Apply("INFO"), 1)
+	if got := formatTheme(t, ftr, true); got != want {
+		t.Fatalf("level scope:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestThemeSegments(t *testing.T) {
+	plain := formatTheme(t, NewNormalFormatter(), false)
+
+	themes := map[string]*Theme{
+		"default":    NewDefaultTheme(),
+		"dark":       NewDarkTheme(),
+		"light":      NewLightTheme(),
+		"monochrome": NewMonochromeBoldTheme(),
+	}
+	for name, theme := range themes {
+		t.Run(name, func(t *testing.T) {
+			got := formatTheme(t, NewNormalThemeFormatter(theme), true)
+			if stripped := string(util.StripANSI([]byte(got))); stripped != plain {
+				t.Fatalf("stripped:\n got %q\nwant %q", stripped, plain)
+			}
+			segments := []struct {
+				stylus *golus.Stylus
+				text   string
+			}{
+				{theme.Time, "2026-10-12 14:40:00"},
+				{theme.Level(INFO), "INFO"},
+				{theme.File, "theme_test.go:42"},
+				{theme.Func, "(TestTheme)"},
+				{theme.Tag, "[db]"},
+				{theme.Body, "query"},
+				{theme.FieldKey, "sql"},
+				{theme.FieldValue, `"select 1"`},
+			}
+			for _, s := range segments {
+				if want := paint(s.stylus, s.text); !strings.Contains(got, want) {
+					t.Fatalf("segment %q not rendered as %q in %q", s.text, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestThemeLevelFallback(t *testing.T) {
+	theme := NewDefaultTheme()
+	if theme.Level(WARN).Apply("x") != Colorful(WARN).Apply("x") {
+		t.Fatal("default theme level should fall back to Colorful")
+	}
+	var nilTheme *Theme
+	if nilTheme.Level(ERROR).Apply("x") != Colorful(ERROR).Apply("x") {
+		t.Fatal("nil theme level should fall back to Colorful")
+	}
+	dark := NewDarkTheme()
+	if dark.Level(FATAL).Apply("x") == Colorful(FATAL).Apply("x") {
+		t.Fatal("dark theme should override FATAL")
+	}
+	if paint(nil, "text") != "text" {
+		t.Fatal("nil stylus should leave text untouched")
+	}
+}
@@ -23,7 +23,16 @@ package util
 import (
 	"path/filepath"
 	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+)
+
+var (
+	modulesOnce sync.Once
+	modules     []string // 构建信息中的模块路径，按长度降序排列
 )
 
 // FileLineNumber 获取调用源的   文件 方法 行号   信息
@@ -40,3 +49,156 @@ func FileLineNumber(callSkip int, shortFile bool) (string, string, int, bool) {
 	}
 	return "", "", 0, false
 }
+
+// GoroutineID 获取当前协程的 id，获取失败时返回 0
+func GoroutineID() uint64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	// 格式为 "goroutine 123 [running]:..."
+	stack := strings.TrimPrefix(string(buf[:n]), "goroutine ")
+	if idx := strings.IndexByte(stack, ' '); idx > 0 {
+		if id, err := strconv.ParseUint(stack[:idx], 10, 64); err == nil {
+			return id
+		}
+	}
+	return 0
+}
+
+// Caller 获取调用源的栈帧，skip 的含义与 runtime.Caller 一致
+//
+// 跳过 skip 层之后，继续跳过 skipFrame 返回 true 的栈帧，全部被跳过时返回 false
+func Caller(skip int, skipFrame func(function string) bool) (runtime.Frame, bool) {
+	var buf [32]uintptr
+	pcs := buf[:]
+	for {
+		n := runtime.Callers(skip+1, pcs)
+		if n == 0 {
+			return runtime.Frame{}, false
+		}
+		frames := runtime.CallersFrames(pcs[:n])
+		for {
+			frame, more := frames.Next()
+			if skipFrame == nil || !skipFrame(frame.Function) {
+				return frame, true
+			}
+			if !more {
+				break
+			}
+		}
+		if n < len(pcs) {
+			return runtime.Frame{}, false
+		}
+		// 调用栈比缓冲更深，扩大缓冲后重新获取
+		pcs = make([]uintptr, len(pcs)*2)
+	}
+}
+
+// Stack 获取调用栈，skip 与 skipFrame 的含义与 Caller 一致，最多返回 depth 层
+//
+// 栈底的 runtime.goexit 会被去除
+func Stack(skip int, skipFrame func(function string) bool, depth int) []runtime.Frame {
+	pcs := make([]uintptr, depth+16)
+	n := runtime.Callers(skip+1, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	result := make([]runtime.Frame, 0, n)
+	skipping := skipFrame != nil
+	for {
+		frame, more := frames.Next()
+		if skipping && skipFrame(frame.Function) {
+			if !more {
+				break
+			}
+			continue
+		}
+		skipping = false
+		if frame.Function != "runtime.goexit" {
+			result = append(result, frame)
+		}
+		if !more || len(result) == depth {
+			break
+		}
+	}
+	return result
+}
+
+// PackagePath 从完整函数名中获取包路径
+//
+// 如 "github.com/yhyzgn/gog.(*Gog).Write" 的包路径为 "github.com/yhyzgn/gog"，
+// 符号名中转义的字符会被还原，如 "gopkg.in/yaml%2ev2.Marshal" 的包路径为 "gopkg.in/yaml.v2"
+func PackagePath(function string) string {
+	lastSlash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[lastSlash+1:], "."); dot >= 0 {
+		return unescapeSymbol(function[:lastSlash+1+dot])
+	}
+	return unescapeSymbol(function)
+}
+
+// unescapeSymbol 还原符号名中以 %xx 形式转义的字符
+func unescapeSymbol(name string) string {
+	if strings.IndexByte(name, '%') < 0 {
+		return name
+	}
+	var sb strings.Builder
+	sb.Grow(len(name))
+	for i := 0; i < len(name); i++ {
+		if name[i] == '%' && i+2 < len(name) {
+			if b, err := strconv.ParseUint(name[i+1:i+3], 16, 8); err == nil {
+				sb.WriteByte(byte(b))
+				i += 2
+				continue
+			}
+		}
+		sb.WriteByte(name[i])
+	}
+	return sb.String()
+}
+
+// ShortFuncName 只保留最后一个 '.' 之后的函数名，如 "Write"
+func ShortFuncName(function string) string {
+	return strings.TrimPrefix(filepath.Ext(function), ".")
+}
+
+// QualifiedFuncName 保留包名的函数名，如 "gog.(*Gog).Write"
+func QualifiedFuncName(function string) string {
+	return function[strings.LastIndex(function, "/")+1:]
+}
+
+// PackageRelativePath 包目录名加文件名，如 "util/runtime.go"
+func PackageRelativePath(file string) string {
+	dir, name := filepath.Split(file)
+	return filepath.Join(filepath.Base(dir), name)
+}
+
+// ModuleRelativePath 文件相对于所在模块根目录的路径，如 "util/runtime.go"
+//
+// 依赖构建信息确定模块路径，无法确定时返回 PackageRelativePath
+func ModuleRelativePath(file, function string) string {
+	pkg := PackagePath(function)
+	modulesOnce.Do(loadModules)
+	for _, module := range modules {
+		if pkg == module {
+			return filepath.Base(file)
+		}
+		if strings.HasPrefix(pkg, module+"/") {
+			return strings.TrimPrefix(pkg, module+"/") + "/" + filepath.Base(file)
+		}
+	}
+	return PackageRelativePath(file)
+}
+
+// loadModules 从构建信息中加载模块路径
+func loadModules() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	if info.Main.Path != "" {
+		modules = append(modules, info.Main.Path)
+	}
+	for _, dep := range info.Deps {
+		modules = append(modules, dep.Path)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		return len(modules[i]) > len(modules[j])
+	})
+}
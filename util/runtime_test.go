@@ -22,6 +22,7 @@ package util
 
 import (
 	"fmt"
+	"runtime"
 	"testing"
 )
 
@@ -32,3 +33,44 @@ func TestFileLineNumber(t *testing.T) {
 func test() {
 	fmt.Println(FileLineNumber(1, true))
 }
+
+func TestPackagePath(t *testing.T) {
+	cases := map[string]string{
+		"github.com/yhyzgn/gog.(*Gog).Write":      "github.com/yhyzgn/gog",
+		"github.com/yhyzgn/gog/util.Caller.func1": "github.com/yhyzgn/gog/util",
+		"gopkg.in/yaml%2ev2.Marshal":              "gopkg.in/yaml.v2",
+		"example.com/a%2eb%2ec.(*T).M":            "example.com/a.b.c",
+		"example.com/bad%zz.F":                    "example.com/bad%zz",
+		"main.main":                               "main",
+		"runtime.goexit":                          "runtime",
+	}
+	for function, want := range cases {
+		if got := PackagePath(function); got != want {
+			t.Errorf("PackagePath(%q) = %q, want %q", function, got, want)
+		}
+	}
+}
+
+// callerDepth 递归 depth 层后获取调用源
+func callerDepth(depth int, skipFrame func(string) bool) (runtime.Frame, bool) {
+	if depth > 0 {
+		return callerDepth(depth-1, skipFrame)
+	}
+	return Caller(1, skipFrame)
+}
+
+func TestCaller(t *testing.T) {
+	skipRecursion := func(function string) bool {
+		return ShortFuncName(function) == "callerDepth"
+	}
+	// 跳过的栈帧超过初始缓冲大小
+	frame, ok := callerDepth(100, skipRecursion)
+	if !ok || ShortFuncName(frame.Function) != "TestCaller" {
+		t.Fatalf("frame = %v, %v", frame.Function, ok)
+	}
+
+	// 全部被跳过
+	if frame, ok = Caller(0, func(string) bool { return true }); ok {
+		t.Fatalf("all frames skipped, got %v", frame.Function)
+	}
+}
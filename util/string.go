@@ -62,9 +62,12 @@ func FillSuffix(src, ch string, ln int) string {
 func Convert(value ...interface{}) string {
 	result := make([]string, 0)
 	for _, item := range value {
-		if temp, err := ToString(item); err == nil {
-			result = append(result, temp)
+		temp, err := ToString(item)
+		if err != nil {
+			// 无法直接转换的类型（结构体、map、切片等）使用默认格式
+			temp = fmt.Sprintf("%+v", item)
 		}
+		result = append(result, temp)
 	}
 	return strings.Join(result, "")
 }
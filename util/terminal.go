@@ -0,0 +1,67 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 13:40
+// version: 1.0.0
+// desc   : 终端工具
+
+package util
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+const esc = 0x1b
+
+// IsTerminal 判断输出目标是否为终端
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// StripANSI 去除 ANSI 转义序列
+func StripANSI(data []byte) []byte {
+	if bytes.IndexByte(data, esc) < 0 {
+		return data
+	}
+	result := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != esc {
+			result = append(result, data[i])
+			continue
+		}
+		if i+1 < len(data) && data[i+1] == '[' {
+			// CSI 序列以 0x40 ~ 0x7E 之间的字节结束
+			i += 2
+			for i < len(data) && (data[i] < 0x40 || data[i] > 0x7e) {
+				i++
+			}
+			continue
+		}
+		// 其他两字节的转义序列
+		i++
+	}
+	return result
+}
@@ -0,0 +1,237 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-24 15:30
+// version: 1.0.0
+// desc   : 缓冲输出器
+
+package gog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// BufferSizeDefault 默认缓冲大小
+	BufferSizeDefault = 64 * 1024
+	// BufferFlushIntervalDefault 默认定时刷新间隔
+	BufferFlushIntervalDefault = time.Second
+)
+
+// BufferedWriter 缓冲输出器
+//
+// 将日志先写入内存缓冲，缓冲已满、到达定时间隔、写入 >= FlushLevel 的日志或调用 Flush 时
+// 再交给被包装的输出器写出，减少系统调用。被包装的是 ConsoleWriter、FileWriter 等字节流输出器时，
+// 连续的同级别日志合并为一次写出，按级别拆分输出的 ConsoleWriter 仍能正确分流；
+// 其他输出器如 RingWriter、AuditWriter 需要每条日志的数据，逐条写出
+type BufferedWriter struct {
+	mu            sync.Mutex
+	inner         Writer
+	size          int              // 缓冲大小
+	flushInterval time.Duration    // 定时刷新间隔
+	flushLevel    Level            // >= 该级别的日志立即刷新，OFF 表示不按级别刷新
+	buf           bytes.Buffer     // 缓冲的日志
+	records       []bufferedRecord // 缓冲中的日志
+	once          sync.Once        // 定时刷新只启动一次
+	done          chan struct{}    // 关闭信号
+	closed        bool             // 是否已关闭
+}
+
+// bufferedRecord 缓冲中的一条日志，end 为其内容在缓冲中的结束位置
+type bufferedRecord struct {
+	info *LogInfo
+	end  int
+}
+
+// NewBufferedWriter 创建缓冲输出器对象，默认 ERROR 及以上级别的日志立即刷新
+func NewBufferedWriter(inner Writer) *BufferedWriter {
+	return &BufferedWriter{
+		inner:         inner,
+		size:          BufferSizeDefault,
+		flushInterval: BufferFlushIntervalDefault,
+		flushLevel:    ERROR,
+		done:          make(chan struct{}),
+	}
+}
+
+// ColorEnabled 被包装的输出器是否支持颜色
+func (bw *BufferedWriter) ColorEnabled(level Level) bool {
+	return writerColorful(bw.inner, level)
+}
+
+// Size 设置缓冲大小
+func (bw *BufferedWriter) Size(size int) *BufferedWriter {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	bw.size = size
+	return bw
+}
+
+// FlushInterval 设置定时刷新间隔，<= 0 时不定时刷新
+func (bw *BufferedWriter) FlushInterval(interval time.Duration) *BufferedWriter {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	bw.flushInterval = interval
+	return bw
+}
+
+// FlushLevel 设置立即刷新的最低日志级别，OFF 表示不按级别刷新
+func (bw *BufferedWriter) FlushLevel(level Level) *BufferedWriter {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	bw.flushLevel = level
+	return bw
+}
+
+// Write 写入缓冲
+func (bw *BufferedWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	bw.once.Do(bw.startFlush)
+
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if bw.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	if bw.buf.Len() > 0 && bw.buf.Len()+len(data) > bw.size {
+		if err = bw.flush(); err != nil {
+			return 0, err
+		}
+	}
+	bw.buf.Write(data)
+	bw.records = append(bw.records, bufferedRecord{info: info, end: bw.buf.Len()})
+
+	if bw.buf.Len() >= bw.size || bw.flushLevel != OFF && info.Level != OFF && info.Level.AtLeast(bw.flushLevel) {
+		if err = bw.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// Flush 将缓冲中的日志交给被包装的输出器，并刷新被包装的输出器
+func (bw *BufferedWriter) Flush() error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	if err := bw.flush(); err != nil {
+		return err
+	}
+	if flusher, ok := bw.inner.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Sync 刷新缓冲，并刷新、同步被包装的输出器
+func (bw *BufferedWriter) Sync() error {
+	bw.mu.Lock()
+	err := bw.flush()
+	bw.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return syncWriter(bw.inner)
+}
+
+// Close 刷新缓冲，并关闭被包装的输出器
+func (bw *BufferedWriter) Close() error {
+	bw.mu.Lock()
+	if bw.closed {
+		bw.mu.Unlock()
+		return nil
+	}
+	bw.closed = true
+	close(bw.done)
+	err := bw.flush()
+	bw.mu.Unlock()
+
+	if closeErr := bw.inner.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// flush 将缓冲中的日志交给被包装的输出器
+//
+// 写出失败时保留未写出的日志，下次刷新时重试
+func (bw *BufferedWriter) flush() error {
+	if len(bw.records) == 0 {
+		return nil
+	}
+	data := bw.buf.Bytes()
+	sw, ok := bw.inner.(streamWriter)
+	stream := ok && sw.stream()
+
+	start := 0
+	for i := 0; i < len(bw.records); {
+		last := i
+		if stream {
+			for last+1 < len(bw.records) && bw.records[last+1].info.Level == bw.records[i].info.Level {
+				last++
+			}
+		}
+		record := bw.records[last]
+		if _, err := bw.inner.Write(record.info, data[start:record.end]); err != nil {
+			bw.buf.Next(start)
+			n := copy(bw.records, bw.records[i:])
+			for j := range bw.records[:n] {
+				bw.records[j].end -= start
+			}
+			bw.resetRecords(n)
+			return err
+		}
+		start, i = record.end, last+1
+	}
+	bw.buf.Reset()
+	bw.resetRecords(0)
+	return nil
+}
+
+// resetRecords 只保留前 n 条日志，并清空其余位置，避免继续引用已写出的日志
+func (bw *BufferedWriter) resetRecords(n int) {
+	for i := n; i < len(bw.records); i++ {
+		bw.records[i] = bufferedRecord{}
+	}
+	bw.records = bw.records[:n]
+}
+
+// startFlush 开启定时刷新
+func (bw *BufferedWriter) startFlush() {
+	bw.mu.Lock()
+	interval := bw.flushInterval
+	bw.mu.Unlock()
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := bw.Flush(); err != nil {
+					log.Println(err)
+				}
+			case <-bw.done:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-24 15:30
+// version: 1.0.0
+// desc   : 缓冲输出器
+
+package gog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBufferedWriter(t *testing.T) {
+	var out, errOut bytes.Buffer
+	bw := NewBufferedWriter(NewConsoleWriterTo(&out, &errOut).ErrLevel(WARN)).FlushInterval(0)
+
+	_, _ = bw.Write(&LogInfo{Level: INFO}, []byte("info1\n"))
+	_, _ = bw.Write(&LogInfo{Level: INFO}, []byte("info2\n"))
+	_, _ = bw.Write(&LogInfo{Level: WARN}, []byte("warn\n"))
+	if out.Len() != 0 || errOut.Len() != 0 {
+		t.Fatalf("flushed too early: %q %q", out.String(), errOut.String())
+	}
+	_, _ = bw.Write(&LogInfo{Level: ERROR}, []byte("error\n"))
+	if out.String() != "info1\ninfo2\n" || errOut.String() != "warn\nerror\n" {
+		t.Fatalf("out = %q, err = %q", out.String(), errOut.String())
+	}
+
+	// 异步输出时，Sync 等待队列中的日志输出后再刷新缓冲
+	out.Reset()
+	g := NewGog(INFO, 0).
+		SetConfig(&Config{Formatter: NewLogfmtFormatter(), Writers: []Writer{bw}}).
+		Async(true)
+	for i := 0; i < 100; i++ {
+		g.Info("buffered")
+	}
+	if err := g.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(out.Bytes(), []byte("msg=buffered")); n != 100 {
+		t.Fatalf("synced %d records, want 100", n)
+	}
+}
+
+func TestBufferedWriterRecords(t *testing.T) {
+	// 非字节流输出器逐条写出，每条日志保留自己的数据
+	rw := NewRingWriter(10)
+	bw := NewBufferedWriter(rw).FlushInterval(0)
+	for _, body := range []string{"a", "b", "c"} {
+		_, _ = bw.Write(&LogInfo{Level: INFO, Body: body}, []byte(body+"\n"))
+	}
+	if err := bw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	records := rw.Records()
+	if len(records) != 3 || records[0].Body != "a" || records[2].Body != "c" {
+		t.Fatalf("records = %v", records)
+	}
+
+	// 写出失败时保留未写出的日志
+	fw := &failingWriter{failAt: 2}
+	bw = NewBufferedWriter(fw).FlushInterval(0)
+	for _, body := range []string{"a", "b", "c"} {
+		_, _ = bw.Write(&LogInfo{Level: INFO, Body: body}, []byte(body+"\n"))
+	}
+	if err := bw.Flush(); err == nil || fw.out.String() != "a\n" {
+		t.Fatalf("first flush = %q, %v", fw.out.String(), err)
+	}
+	_, _ = bw.Write(&LogInfo{Level: INFO, Body: "d"}, []byte("d\n"))
+	if err := bw.Flush(); err != nil || fw.out.String() != "a\nb\nc\nd\n" {
+		t.Fatalf("retry = %q, %v", fw.out.String(), err)
+	}
+}
+
+// failingWriter 第 failAt 次写入失败的输出器
+type failingWriter struct {
+	out    bytes.Buffer
+	writes int
+	failAt int
+}
+
+func (fw *failingWriter) Write(_ *LogInfo, data []byte) (int, error) {
+	fw.writes++
+	if fw.writes == fw.failAt {
+		return 0, errors.New("write failed")
+	}
+	return fw.out.Write(data)
+}
+
+func (fw *failingWriter) Close() error {
+	return nil
+}
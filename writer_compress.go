@@ -0,0 +1,220 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-24 10:20
+// version: 1.0.0
+// desc   : 压缩输出器
+
+package gog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// CompressBlockSizeDefault 默认每块压缩前的最大字节数
+	CompressBlockSizeDefault = 256 * 1024
+	// CompressFlushIntervalDefault 默认定时输出压缩块的间隔
+	CompressFlushIntervalDefault = 5 * time.Second
+)
+
+// Codec 压缩算法
+//
+// 每个压缩块都由新的编码器生成，Close 后即为一个可以独立解压的完整块。
+// 内置 gzip，zstd 由子模块 github.com/yhyzgn/gog/gogzstd 提供，避免核心包引入第三方依赖
+type Codec interface {
+	NewEncoder(w io.Writer) (io.WriteCloser, error)
+}
+
+// gzipCodec gzip 压缩算法
+type gzipCodec struct {
+	level int
+}
+
+// GzipCodec gzip 压缩算法，每块为一个 gzip member，拼接后仍是合法的 gzip 文件
+func GzipCodec(level int) Codec {
+	return gzipCodec{level: level}
+}
+
+// NewEncoder 创建编码器
+func (gc gzipCodec) NewEncoder(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, gc.level)
+}
+
+// CompressWriter 压缩输出器
+//
+// 将日志压缩为可独立解压的块后交给被包装的输出器写出，块达到 BlockSize、
+// 到达定时间隔或关闭时输出当前块
+type CompressWriter struct {
+	mu            sync.Mutex
+	inner         Writer
+	codec         Codec
+	blockSize     int            // 每块压缩前的最大字节数
+	flushInterval time.Duration  // 定时输出间隔
+	block         bytes.Buffer   // 当前块的压缩数据
+	encoder       io.WriteCloser // 当前块的编码器
+	size          int            // 当前块压缩前的字节数
+	last          *LogInfo       // 当前块中最后一条日志，随块一起交给被包装的输出器
+	once          sync.Once      // 定时输出只启动一次
+	done          chan struct{}  // 关闭信号
+	closed        bool           // 是否已关闭
+}
+
+// NewCompressWriter 创建压缩输出器对象，codec 为 nil 时使用默认级别的 gzip
+func NewCompressWriter(inner Writer, codec Codec) *CompressWriter {
+	if codec == nil {
+		codec = GzipCodec(gzip.DefaultCompression)
+	}
+	return &CompressWriter{
+		inner:         inner,
+		codec:         codec,
+		blockSize:     CompressBlockSizeDefault,
+		flushInterval: CompressFlushIntervalDefault,
+		done:          make(chan struct{}),
+	}
+}
+
+// BlockSize 设置每块压缩前的最大字节数
+func (cw *CompressWriter) BlockSize(size int) *CompressWriter {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.blockSize = size
+	return cw
+}
+
+// FlushInterval 设置定时输出间隔，<= 0 时不定时输出
+func (cw *CompressWriter) FlushInterval(interval time.Duration) *CompressWriter {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	cw.flushInterval = interval
+	return cw
+}
+
+// Write 压缩到当前块，块已满时输出
+func (cw *CompressWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	cw.once.Do(cw.startFlush)
+
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	if cw.closed {
+		return 0, io.ErrClosedPipe
+	}
+	if cw.encoder == nil {
+		if cw.encoder, err = cw.codec.NewEncoder(&cw.block); err != nil {
+			return 0, err
+		}
+	}
+	if _, err = cw.encoder.Write(data); err != nil {
+		return 0, err
+	}
+	cw.size += len(data)
+	cw.last = info
+	if cw.size >= cw.blockSize {
+		if err = cw.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// Flush 立即输出当前块，并刷新被包装的输出器
+func (cw *CompressWriter) Flush() error {
+	cw.mu.Lock()
+	err := cw.flush()
+	cw.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if flusher, ok := cw.inner.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Sync 输出当前块，并刷新、同步被包装的输出器
+func (cw *CompressWriter) Sync() error {
+	cw.mu.Lock()
+	err := cw.flush()
+	cw.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return syncWriter(cw.inner)
+}
+
+// Close 输出当前块，并关闭被包装的输出器
+func (cw *CompressWriter) Close() error {
+	cw.mu.Lock()
+	if cw.closed {
+		cw.mu.Unlock()
+		return nil
+	}
+	cw.closed = true
+	close(cw.done)
+	err := cw.flush()
+	cw.mu.Unlock()
+
+	if closeErr := cw.inner.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// flush 结束当前块并交给被包装的输出器
+func (cw *CompressWriter) flush() error {
+	if cw.encoder == nil {
+		return nil
+	}
+	err := cw.encoder.Close()
+	block := append([]byte{}, cw.block.Bytes()...)
+	last := cw.last
+	cw.encoder, cw.size, cw.last = nil, 0, nil
+	cw.block.Reset()
+	if err != nil {
+		return err
+	}
+	_, err = cw.inner.Write(last, block)
+	return err
+}
+
+// startFlush 开启定时输出
+func (cw *CompressWriter) startFlush() {
+	cw.mu.Lock()
+	interval := cw.flushInterval
+	cw.mu.Unlock()
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := cw.Flush(); err != nil {
+					log.Println(err)
+				}
+			case <-cw.done:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-24 10:20
+// version: 1.0.0
+// desc   : 压缩输出器
+
+package gog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+func TestCompressWriter(t *testing.T) {
+	var blocks [][]byte
+	rec := &blockRecorder{blocks: &blocks}
+	cw := NewCompressWriter(rec, nil).BlockSize(10).FlushInterval(0)
+
+	info := &LogInfo{Level: INFO}
+	_, _ = cw.Write(info, []byte("first line\n"))
+	_, _ = cw.Write(info, []byte("second\n"))
+	if len(blocks) != 1 {
+		t.Fatalf("blocks = %d, want 1", len(blocks))
+	}
+	if err := cw.Flush(); err != nil || len(blocks) != 2 || rec.flushes != 1 {
+		t.Fatalf("flush: blocks = %d, inner flushes = %d, %v", len(blocks), rec.flushes, err)
+	}
+	_ = cw.Close()
+	if len(blocks) != 2 || !rec.closed {
+		t.Fatalf("blocks = %d, closed = %v", len(blocks), rec.closed)
+	}
+
+	// 每块可以独立解压
+	for i, want := range []string{"first line\n", "second\n"} {
+		gz, err := gzip.NewReader(bytes.NewReader(blocks[i]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		gz.Multistream(false)
+		if data, _ := io.ReadAll(gz); string(data) != want {
+			t.Fatalf("block %d = %q, want %q", i, data, want)
+		}
+	}
+	// 拼接后是合法的 gzip 文件
+	gz, _ := gzip.NewReader(bytes.NewReader(bytes.Join(blocks, nil)))
+	if data, _ := io.ReadAll(gz); string(data) != "first line\nsecond\n" {
+		t.Fatalf("joined = %q", data)
+	}
+}
+
+// blockRecorder 记录写出的每个块
+type blockRecorder struct {
+	blocks  *[][]byte
+	flushes int
+	closed  bool
+}
+
+func (br *blockRecorder) Write(info *LogInfo, data []byte) (int, error) {
+	*br.blocks = append(*br.blocks, data)
+	return len(data), nil
+}
+
+func (br *blockRecorder) Flush() error {
+	br.flushes++
+	return nil
+}
+
+func (br *blockRecorder) Close() error {
+	br.closed = true
+	return nil
+}
@@ -26,23 +26,86 @@ import (
 )
 
 // ConsoleWriter 控制台输出器
+//
+// 默认 WARN 及以上级别的日志输出到标准错误输出，其余输出到标准输出，可通过 ErrLevel 调整；
+// 通过 ColorEnabled 告知格式化输出目标是否支持颜色（终端、NO_COLOR、FORCE_COLOR）
 type ConsoleWriter struct {
-	out io.WriteCloser
+	out      io.Writer // 标准输出
+	err      io.Writer // 错误输出
+	errLevel Level     // >= 该级别的日志输出到 err，OFF 表示不拆分，默认 WARN
+	outColor bool      // out 是否支持颜色
+	errColor bool      // err 是否支持颜色
 }
 
-// NewConsoleWriter 创建控制台输出器对象
+// NewConsoleWriter 创建控制台输出器对象，WARN 及以上级别的日志输出到标准错误输出
 func NewConsoleWriter() *ConsoleWriter {
+	return NewConsoleWriterTo(os.Stdout, os.Stderr)
+}
+
+// NewConsoleWriterTo 创建输出到指定目标的控制台输出器对象
+func NewConsoleWriterTo(out, err io.Writer) *ConsoleWriter {
+	if err == nil {
+		err = out
+	}
 	return &ConsoleWriter{
-		out: os.Stdout,
+		out:      out,
+		err:      err,
+		errLevel: WARN,
+		outColor: ColorEnabled(out),
+		errColor: ColorEnabled(err),
+	}
+}
+
+// ErrLevel 设置输出到错误输出的最低级别，OFF 表示全部输出到标准输出
+func (cw *ConsoleWriter) ErrLevel(level Level) *ConsoleWriter {
+	cw.errLevel = level
+	return cw
+}
+
+// ColorEnabled 该级别日志的输出目标是否支持颜色
+func (cw *ConsoleWriter) ColorEnabled(level Level) bool {
+	if cw.toErr(level) {
+		return cw.errColor
 	}
+	return cw.outColor
 }
 
 // Write 输出日志
 func (cw *ConsoleWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	if cw.toErr(info.Level) {
+		return cw.err.Write(data)
+	}
 	return cw.out.Write(data)
 }
 
-// Close 关闭输出流
+// toErr 该级别日志是否输出到错误输出
+func (cw *ConsoleWriter) toErr(level Level) bool {
+	return cw.errLevel != OFF && level != OFF && level.AtLeast(cw.errLevel)
+}
+
+// stream 只按级别选择输出目标，可以合并写出
+func (cw *ConsoleWriter) stream() bool {
+	return true
+}
+
+// Close 关闭输出流，标准输出和标准错误输出不会被关闭
 func (cw *ConsoleWriter) Close() error {
-	return cw.out.Close()
+	if err := closeNonStd(cw.out); err != nil {
+		return err
+	}
+	if cw.err != cw.out {
+		return closeNonStd(cw.err)
+	}
+	return nil
+}
+
+// closeNonStd 关闭非标准输出流
+func closeNonStd(w io.Writer) error {
+	if w == os.Stdout || w == os.Stderr {
+		return nil
+	}
+	if c, ok := w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
 }
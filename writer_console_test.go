@@ -0,0 +1,111 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 13:40
+// version: 1.0.0
+// desc   : 控制台输出器测试
+
+package gog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// bytesWriter 不支持颜色的输出器，模拟文件、网络等输出目标
+type bytesWriter struct {
+	bytes.Buffer
+}
+
+func (bw *bytesWriter) Write(info *LogInfo, data []byte) (int, error) {
+	return bw.Buffer.Write(data)
+}
+
+func (bw *bytesWriter) Close() error {
+	return nil
+}
+
+func newConsoleTestGog(writers ...Writer) *Gog {
+	return NewGog(TRACE, 0).SetConfig(&Config{Formatter: NewNormalColorfulFormatter(), Writers: writers})
+}
+
+func TestConsoleWriterSplit(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("NO_COLOR", "")
+
+	var out, errOut bytes.Buffer
+	g := newConsoleTestGog(NewConsoleWriterTo(&out, &errOut))
+	g.Info("to stdout")
+	g.Warn("to stderr")
+	g.Error("to stderr too")
+	if got := strings.Count(out.String(), "\n"); got != 1 || !strings.Contains(out.String(), "to stdout") {
+		t.Fatalf("stdout = %q", out.String())
+	}
+	if got := strings.Count(errOut.String(), "\n"); got != 2 || strings.Contains(errOut.String(), "to stdout") {
+		t.Fatalf("stderr = %q", errOut.String())
+	}
+
+	out.Reset()
+	errOut.Reset()
+	g = newConsoleTestGog(NewConsoleWriterTo(&out, &errOut).ErrLevel(ERROR))
+	g.Warn("warn")
+	g.Error("error")
+	if !strings.Contains(out.String(), "warn") || !strings.Contains(errOut.String(), "error") || strings.Contains(errOut.String(), "warn") {
+		t.Fatalf("stdout = %q, stderr = %q", out.String(), errOut.String())
+	}
+
+	out.Reset()
+	errOut.Reset()
+	g = newConsoleTestGog(NewConsoleWriterTo(&out, &errOut).ErrLevel(OFF))
+	g.Error("error")
+	if errOut.Len() != 0 || !strings.Contains(out.String(), "error") {
+		t.Fatalf("stdout = %q, stderr = %q", out.String(), errOut.String())
+	}
+}
+
+func TestConsoleWriterColorEnv(t *testing.T) {
+	cases := []struct {
+		name       string
+		forceColor string
+		noColor    string
+		want       bool
+	}{
+		{"not a terminal", "", "", false},
+		{"NO_COLOR", "", "1", false},
+		{"FORCE_COLOR", "1", "", true},
+		{"FORCE_COLOR wins over NO_COLOR", "1", "1", true},
+		{"FORCE_COLOR=0", "0", "", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv("FORCE_COLOR", c.forceColor)
+			t.Setenv("NO_COLOR", c.noColor)
+
+			var out bytes.Buffer
+			file := &bytesWriter{}
+			g := newConsoleTestGog(NewConsoleWriterTo(&out, nil), file)
+			g.Info("hello")
+			if got := strings.Contains(out.String(), "\x1b["); got != c.want {
+				t.Fatalf("console colorful = %v, want %v: %q", got, c.want, out.String())
+			}
+			// 不支持颜色的输出器始终不上色
+			if strings.Contains(file.String(), "\x1b[") || !strings.Contains(file.String(), "hello") {
+				t.Fatalf("file = %q", file.String())
+			}
+		})
+	}
+}
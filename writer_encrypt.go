@@ -0,0 +1,207 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 15:10
+// version: 1.0.0
+// desc   : 加密输出器
+
+package gog
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// 加密帧格式：
+//
+//	magic "GOGE" | 版本 1 字节 | 密钥 id 长度 1 字节 | 密钥 id | nonce 12 字节 | 密文长度 4 字节 | 密文
+//
+// 每次写入的日志单独加密为一帧，帧头作为 GCM 附加数据参与认证；
+// 文件被截断时，之前完整的帧仍然可以解密
+const (
+	encryptMagic   = "GOGE"
+	encryptVersion = 1
+	encryptNonce   = 12
+
+	// maxFrameSize 单帧密文的最大长度，解密时拒绝更大的帧，避免损坏的长度字段导致巨大的内存分配
+	maxFrameSize = 16 << 20
+
+	// MaxFramesPerKey 同一个密钥最多加密的帧数
+	//
+	// 每帧使用随机的 96 位 nonce，按 NIST SP 800-38D 的要求，同一密钥下随机 nonce 的加密次数不能超过 2^32，
+	// 否则 nonce 重复的概率超过 2^-32，重复后 GCM 的机密性和认证都会失效
+	MaxFramesPerKey uint64 = 1 << 32
+)
+
+var (
+	// ErrTruncatedFrame 加密日志的最后一帧不完整，通常是写入过程中进程退出
+	ErrTruncatedFrame = errors.New("gog: truncated encrypted frame")
+	// ErrFrameTooLarge 单条日志加密后超过 maxFrameSize
+	ErrFrameTooLarge = errors.New("gog: encrypted frame too large")
+	// ErrKeyExhausted 当前密钥加密的帧数已达到 MaxFramesPerKey，需要 Rotate 轮换密钥
+	ErrKeyExhausted = errors.New("gog: encryption key exhausted, rotate the key")
+)
+
+// EncryptWriter 加密输出器
+//
+// 使用 AES-GCM 加密格式化后的日志，再交给被包装的输出器写出，如 FileWriter；
+// 通过 Rotate 轮换密钥，帧头中记录了密钥 id，解密时按 id 选择密钥。
+// 同一密钥加密 MaxFramesPerKey 帧后 Write 返回 ErrKeyExhausted；帧数只在进程内统计，
+// 多个进程或重启后继续使用同一密钥时需要自行控制总量，建议定期或每次启动时轮换密钥
+type EncryptWriter struct {
+	mu     sync.Mutex
+	inner  Writer
+	keyID  string
+	aead   cipher.AEAD
+	frames uint64 // 当前密钥已加密的帧数
+}
+
+// NewEncryptWriter 创建加密输出器对象，key 为 16、24 或 32 字节的 AES 密钥
+func NewEncryptWriter(inner Writer, keyID string, key []byte) (*EncryptWriter, error) {
+	ew := &EncryptWriter{inner: inner}
+	if err := ew.Rotate(keyID, key); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// Rotate 切换加密密钥，之后写入的日志使用新密钥
+func (ew *EncryptWriter) Rotate(keyID string, key []byte) error {
+	if keyID == "" || len(keyID) > 255 {
+		return errors.New("gog: key id must be 1 to 255 bytes")
+	}
+	aead, err := newEncryptAEAD(key)
+	if err != nil {
+		return err
+	}
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+	ew.keyID, ew.aead, ew.frames = keyID, aead, 0
+	return nil
+}
+
+// Write 加密后写出
+func (ew *EncryptWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+
+	if len(data)+ew.aead.Overhead() > maxFrameSize {
+		return 0, ErrFrameTooLarge
+	}
+	if ew.frames >= MaxFramesPerKey {
+		return 0, ErrKeyExhausted
+	}
+	header := make([]byte, 0, 6+len(ew.keyID)+encryptNonce+4)
+	header = append(header, encryptMagic...)
+	header = append(header, encryptVersion, byte(len(ew.keyID)))
+	header = append(header, ew.keyID...)
+	nonce := make([]byte, encryptNonce)
+	if _, err = rand.Read(nonce); err != nil {
+		return 0, err
+	}
+	header = append(header, nonce...)
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)+ew.aead.Overhead()))
+	header = append(header, size[:]...)
+
+	frame := ew.aead.Seal(header, nonce, data, header)
+	ew.frames++
+	if _, err = ew.inner.Write(info, frame); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Sync 刷新、同步被包装的输出器
+func (ew *EncryptWriter) Sync() error {
+	return syncWriter(ew.inner)
+}
+
+// Close 关闭被包装的输出器
+func (ew *EncryptWriter) Close() error {
+	return ew.inner.Close()
+}
+
+// DecryptLog 解密加密输出器写出的日志，keys 为密钥 id 到密钥的映射
+//
+// 最后一帧不完整时，先写出之前的全部内容再返回 ErrTruncatedFrame
+func DecryptLog(r io.Reader, w io.Writer, keys map[string][]byte) error {
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for {
+		prefix := make([]byte, 6)
+		if n, err := io.ReadFull(r, prefix); err != nil {
+			if n == 0 && err == io.EOF {
+				return nil
+			}
+			return ErrTruncatedFrame
+		}
+		if string(prefix[:4]) != encryptMagic || prefix[4] != encryptVersion {
+			return errors.New("gog: invalid encrypted frame header")
+		}
+
+		rest := make([]byte, int(prefix[5])+encryptNonce+4)
+		if _, err := io.ReadFull(r, rest); err != nil {
+			return ErrTruncatedFrame
+		}
+		header := append(prefix, rest...)
+		keyID := string(rest[:prefix[5]])
+		nonce := rest[prefix[5] : int(prefix[5])+encryptNonce]
+		size := binary.BigEndian.Uint32(rest[len(rest)-4:])
+		if size > maxFrameSize {
+			return ErrFrameTooLarge
+		}
+
+		ciphertext := make([]byte, size)
+		if _, err := io.ReadFull(r, ciphertext); err != nil {
+			return ErrTruncatedFrame
+		}
+
+		aead, ok := aeads[keyID]
+		if !ok {
+			key, exists := keys[keyID]
+			if !exists {
+				return fmt.Errorf("gog: unknown key id %q", keyID)
+			}
+			var err error
+			if aead, err = newEncryptAEAD(key); err != nil {
+				return err
+			}
+			aeads[keyID] = aead
+		}
+		plaintext, err := aead.Open(nil, nonce, ciphertext, header)
+		if err != nil {
+			return fmt.Errorf("gog: decrypt frame with key %q: %v", keyID, err)
+		}
+		if _, err = w.Write(plaintext); err != nil {
+			return err
+		}
+	}
+}
+
+// newEncryptAEAD 创建 AES-GCM
+func newEncryptAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
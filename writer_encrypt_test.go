@@ -0,0 +1,121 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 15:10
+// version: 1.0.0
+// desc   : 加密输出器
+
+package gog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log.enc")
+	fw, err := NewFileWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := map[string][]byte{
+		"k1": bytes.Repeat([]byte{1}, 32),
+		"k2": bytes.Repeat([]byte{2}, 16),
+	}
+	ew, err := NewEncryptWriter(fw, "k1", keys["k1"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = ew.Write(&LogInfo{}, []byte("first\n"))
+	_, _ = ew.Write(&LogInfo{}, []byte("second\n"))
+	if err = ew.Rotate("k2", keys["k2"]); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = ew.Write(&LogInfo{}, []byte("third\n"))
+	_ = ew.Close()
+
+	data, _ := os.ReadFile(path)
+	if bytes.Contains(data, []byte("first")) {
+		t.Fatal("plaintext found in encrypted file")
+	}
+	var out bytes.Buffer
+	if err = DecryptLog(bytes.NewReader(data), &out, keys); err != nil || out.String() != "first\nsecond\nthird\n" {
+		t.Fatalf("decrypt = %q, %v", out.String(), err)
+	}
+
+	out.Reset()
+	if err = DecryptLog(bytes.NewReader(data[:len(data)-3]), &out, keys); err != ErrTruncatedFrame || out.String() != "first\nsecond\n" {
+		t.Fatalf("truncated = %q, %v", out.String(), err)
+	}
+	if err = DecryptLog(bytes.NewReader(data), &out, map[string][]byte{"k1": keys["k1"]}); err == nil {
+		t.Fatal("missing key should fail")
+	}
+}
+
+func TestEncryptFrameSize(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, 32)
+	rw := NewRingWriter(1)
+	ew, err := NewEncryptWriter(rw, "k1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ew.Write(&LogInfo{}, make([]byte, maxFrameSize)); err != ErrFrameTooLarge {
+		t.Fatalf("write = %v, want ErrFrameTooLarge", err)
+	}
+
+	if _, err = ew.Write(&LogInfo{}, make([]byte, maxFrameSize-ew.aead.Overhead())); err != nil {
+		t.Fatalf("write at limit = %v", err)
+	}
+
+	// 长度字段超过上限时直接报错，不按该长度分配内存；恰好等于上限时按正常帧读取
+	header := func(size uint32) []byte {
+		frame := []byte(encryptMagic)
+		frame = append(frame, encryptVersion, 2, 'k', '1')
+		frame = append(frame, make([]byte, encryptNonce)...)
+		return append(frame, byte(size>>24), byte(size>>16), byte(size>>8), byte(size))
+	}
+	keys := map[string][]byte{"k1": key}
+	for size, want := range map[uint32]error{
+		maxFrameSize + 1: ErrFrameTooLarge,
+		0xffffffff:       ErrFrameTooLarge,
+		maxFrameSize:     ErrTruncatedFrame,
+	} {
+		var out bytes.Buffer
+		if err = DecryptLog(bytes.NewReader(header(size)), &out, keys); err != want {
+			t.Errorf("decrypt size %d = %v, want %v", size, err, want)
+		}
+	}
+}
+
+func TestEncryptKeyExhausted(t *testing.T) {
+	rw := NewRingWriter(1)
+	ew, err := NewEncryptWriter(rw, "k1", bytes.Repeat([]byte{1}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ew.frames = MaxFramesPerKey
+	if _, err = ew.Write(&LogInfo{}, []byte("x\n")); err != ErrKeyExhausted {
+		t.Fatalf("write = %v, want ErrKeyExhausted", err)
+	}
+	if err = ew.Rotate("k2", bytes.Repeat([]byte{2}, 32)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ew.Write(&LogInfo{}, []byte("x\n")); err != nil {
+		t.Fatalf("write after rotate = %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-23 15:10
+// version: 1.0.0
+// desc   : 文件输出器
+
+package gog
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileWriter 文件输出器，以追加方式写入
+type FileWriter struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+// NewFileWriter 创建文件输出器对象，文件所在目录不存在时自动创建
+func NewFileWriter(path string) (*FileWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileWriter{path: path, file: file}, nil
+}
+
+// Name 输出器名称，为文件路径
+func (fw *FileWriter) Name() string {
+	return fw.path
+}
+
+// Write 写入日志
+func (fw *FileWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.file == nil {
+		return 0, os.ErrClosed
+	}
+	return fw.file.Write(data)
+}
+
+// stream 按字节流写入文件，可以合并写出
+func (fw *FileWriter) stream() bool {
+	return true
+}
+
+// Sync 将已写入的内容同步到磁盘
+func (fw *FileWriter) Sync() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.file == nil {
+		return nil
+	}
+	return fw.file.Sync()
+}
+
+// Close 关闭文件
+func (fw *FileWriter) Close() error {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	if fw.file == nil {
+		return nil
+	}
+	err := fw.file.Close()
+	fw.file = nil
+	return err
+}
@@ -0,0 +1,722 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 10:30
+// version: 1.0.0
+// desc   : OpenTelemetry OTLP/HTTP 日志导出输出器
+
+package gog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// OTLPBatchSizeDefault 默认每批导出的日志条数
+	OTLPBatchSizeDefault = 512
+	// OTLPFlushIntervalDefault 默认定时导出间隔
+	OTLPFlushIntervalDefault = 5 * time.Second
+	// OTLPQueueSizeDefault 默认等待导出的批次数
+	OTLPQueueSizeDefault = 8
+	// OTLPMaxRetriesDefault 默认导出失败后的重试次数
+	OTLPMaxRetriesDefault = 3
+	// OTLPRetryBackoffDefault 默认首次重试的等待时间，之后每次翻倍
+	OTLPRetryBackoffDefault = 500 * time.Millisecond
+)
+
+// OTLPEncoding OTLP/HTTP 请求体编码
+type OTLPEncoding int
+
+const (
+	OTLPJSON     OTLPEncoding = iota // application/json
+	OTLPProtobuf                     // application/x-protobuf
+)
+
+// OTLPWriter OpenTelemetry OTLP/HTTP 日志导出输出器
+//
+// 将日志转换为 OTLP LogRecord，按批次或定时 POST 到采集端，如 http://localhost:4318/v1/logs。
+// 已满的批次交给后台协程导出，写入不会等待网络请求；等待导出的批次过多或重试后仍失败时丢弃该批次，
+// 丢弃的日志条数可通过 Dropped 获取。
+// 字段 trace_id / span_id（或 traceId / spanId）为十六进制字符串时，作为日志的链路 id 导出
+type OTLPWriter struct {
+	mu            sync.Mutex
+	endpoint      string            // 采集端地址
+	encoding      OTLPEncoding      // 请求体编码
+	client        *http.Client      // http 客户端
+	headers       map[string]string // 附加请求头
+	resource      []Field           // 资源属性
+	batchSize     int               // 每批导出的日志条数
+	flushInterval time.Duration     // 定时导出间隔
+	queueSize     int               // 等待导出的批次数
+	maxRetries    int               // 导出失败后的重试次数
+	retryBackoff  time.Duration     // 首次重试的等待时间
+	batch         []*LogInfo        // 待导出的日志
+	queue         chan otlpBatch    // 等待后台协程导出的批次
+	dropped       uint64            // 丢弃的日志条数
+	once          sync.Once         // 后台协程只启动一次
+	done          chan struct{}     // 关闭信号
+	exited        chan struct{}     // 后台协程已退出
+	closed        bool              // 是否已关闭
+}
+
+// otlpBatch 等待导出的批次，result 不为 nil 时导出后回传结果
+type otlpBatch struct {
+	records []*LogInfo
+	result  chan error
+}
+
+// otlpAttr OTLP 属性，值为 string、int64、float64 或 bool
+type otlpAttr struct {
+	key   string
+	value interface{}
+}
+
+// otlpRecord OTLP LogRecord
+type otlpRecord struct {
+	time           uint64
+	observed       uint64
+	severityNumber int
+	severityText   string
+	body           string
+	attrs          []otlpAttr
+	traceID        []byte
+	spanID         []byte
+}
+
+// NewOTLPWriter 创建 OTLP/HTTP 日志导出输出器
+func NewOTLPWriter(endpoint string) *OTLPWriter {
+	return &OTLPWriter{
+		endpoint:      endpoint,
+		encoding:      OTLPJSON,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		headers:       make(map[string]string),
+		batchSize:     OTLPBatchSizeDefault,
+		flushInterval: OTLPFlushIntervalDefault,
+		queueSize:     OTLPQueueSizeDefault,
+		maxRetries:    OTLPMaxRetriesDefault,
+		retryBackoff:  OTLPRetryBackoffDefault,
+		done:          make(chan struct{}),
+		exited:        make(chan struct{}),
+	}
+}
+
+// Encoding 设置请求体编码，默认 OTLPJSON
+func (ow *OTLPWriter) Encoding(encoding OTLPEncoding) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.encoding = encoding
+	return ow
+}
+
+// Client 设置 http 客户端
+func (ow *OTLPWriter) Client(client *http.Client) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.client = client
+	return ow
+}
+
+// Header 添加请求头，如鉴权信息
+func (ow *OTLPWriter) Header(key, value string) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.headers[key] = value
+	return ow
+}
+
+// Resource 添加资源属性，如 String("service.name", "order")
+func (ow *OTLPWriter) Resource(fields ...Field) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.resource = append(ow.resource, fields...)
+	return ow
+}
+
+// BatchSize 设置每批导出的日志条数，<= 1 时每条日志单独导出
+func (ow *OTLPWriter) BatchSize(size int) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.batchSize = size
+	return ow
+}
+
+// FlushInterval 设置定时导出间隔，<= 0 时不定时导出
+func (ow *OTLPWriter) FlushInterval(interval time.Duration) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.flushInterval = interval
+	return ow
+}
+
+// QueueSize 设置等待导出的批次数，超出时丢弃新的批次，需在首次写入前设置
+func (ow *OTLPWriter) QueueSize(size int) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.queueSize = size
+	return ow
+}
+
+// Retry 设置导出失败后的重试次数和首次重试的等待时间，之后每次等待时间翻倍
+//
+// 只有网络错误、429 和 5xx 响应会重试
+func (ow *OTLPWriter) Retry(maxRetries int, backoff time.Duration) *OTLPWriter {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	ow.maxRetries = maxRetries
+	ow.retryBackoff = backoff
+	return ow
+}
+
+// Dropped 等待导出的批次过多或重试后仍导出失败而丢弃的日志条数
+func (ow *OTLPWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&ow.dropped)
+}
+
+// Write 添加到待导出批次，批次已满时交给后台协程导出
+func (ow *OTLPWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	ow.once.Do(ow.start)
+
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	if ow.closed {
+		return 0, io.ErrClosedPipe
+	}
+	ow.batch = append(ow.batch, info.Clone())
+	if len(ow.batch) >= ow.batchSize {
+		batch := ow.batch
+		ow.batch = nil
+		select {
+		case ow.queue <- otlpBatch{records: batch}:
+		default:
+			// 后台协程跟不上时丢弃，不阻塞日志输出
+			atomic.AddUint64(&ow.dropped, uint64(len(batch)))
+		}
+	}
+	return len(data), nil
+}
+
+// Flush 导出所有待导出的日志，等待导出完成，返回最后一批的导出结果
+func (ow *OTLPWriter) Flush() error {
+	ow.once.Do(ow.start)
+
+	ow.mu.Lock()
+	batch := ow.batch
+	ow.batch = nil
+	ow.mu.Unlock()
+
+	// 与已满的批次排队，保证之前的批次都已导出
+	result := make(chan error, 1)
+	select {
+	case ow.queue <- otlpBatch{records: batch, result: result}:
+	case <-ow.exited:
+		return ow.export(batch)
+	}
+	select {
+	case err := <-result:
+		return err
+	case <-ow.exited:
+		// 后台协程已退出，批次未被导出时直接导出
+		select {
+		case err := <-result:
+			return err
+		default:
+			return ow.export(batch)
+		}
+	}
+}
+
+// Close 导出剩余的日志并停止后台协程
+func (ow *OTLPWriter) Close() error {
+	ow.mu.Lock()
+	if ow.closed {
+		ow.mu.Unlock()
+		return nil
+	}
+	ow.closed = true
+	ow.mu.Unlock()
+
+	err := ow.Flush()
+	close(ow.done)
+	<-ow.exited
+	return err
+}
+
+// start 启动后台导出协程
+func (ow *OTLPWriter) start() {
+	ow.mu.Lock()
+	interval := ow.flushInterval
+	size := ow.queueSize
+	ow.mu.Unlock()
+	if size < 1 {
+		size = 1
+	}
+	ow.queue = make(chan otlpBatch, size)
+	go ow.loop(interval)
+}
+
+// loop 后台导出协程，导出排队的批次并定时导出
+func (ow *OTLPWriter) loop(interval time.Duration) {
+	defer close(ow.exited)
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case batch := <-ow.queue:
+			ow.handle(batch)
+		case <-tick:
+			ow.mu.Lock()
+			batch := ow.batch
+			ow.batch = nil
+			ow.mu.Unlock()
+			ow.handle(otlpBatch{records: batch})
+		case <-ow.done:
+			// 导出关闭前已排队的批次
+			for {
+				select {
+				case batch := <-ow.queue:
+					ow.handle(batch)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// handle 导出批次，失败时计入丢弃的日志条数
+func (ow *OTLPWriter) handle(batch otlpBatch) {
+	err := ow.export(batch.records)
+	if err != nil {
+		atomic.AddUint64(&ow.dropped, uint64(len(batch.records)))
+	}
+	if batch.result != nil {
+		batch.result <- err
+	} else if err != nil {
+		log.Println(err)
+	}
+}
+
+// export 导出日志，可重试的错误按退避时间重试
+func (ow *OTLPWriter) export(batch []*LogInfo) error {
+	if len(batch) == 0 {
+		return nil
+	}
+	ow.mu.Lock()
+	maxRetries, backoff := ow.maxRetries, ow.retryBackoff
+	ow.mu.Unlock()
+
+	body, contentType := ow.encode(batch)
+	for attempt := 0; ; attempt++ {
+		retryable, err := ow.post(body, contentType)
+		if err == nil || !retryable || attempt >= maxRetries {
+			return err
+		}
+		select {
+		case <-time.After(backoff << uint(attempt)):
+		case <-ow.done:
+			// 关闭时不再等待重试
+			return err
+		}
+	}
+}
+
+// encode 编码请求体
+func (ow *OTLPWriter) encode(batch []*LogInfo) ([]byte, string) {
+	ow.mu.Lock()
+	encoding := ow.encoding
+	resource := ow.resource
+	ow.mu.Unlock()
+
+	records := make([]otlpRecord, 0, len(batch))
+	for _, info := range batch {
+		records = append(records, newOTLPRecord(info))
+	}
+	attrs := otlpAttrs(resource)
+	if encoding == OTLPProtobuf {
+		return encodeOTLPProtobuf(attrs, records), "application/x-protobuf"
+	}
+	return encodeOTLPJSON(attrs, records), "application/json"
+}
+
+// post 发送请求，返回错误是否可以重试
+func (ow *OTLPWriter) post(body []byte, contentType string) (bool, error) {
+	ow.mu.Lock()
+	client, endpoint := ow.client, ow.endpoint
+	headers := make(map[string]string, len(ow.headers))
+	for k, v := range ow.headers {
+		headers[k] = v
+	}
+	ow.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retryable, fmt.Errorf("gog: otlp export failed: %s", resp.Status)
+	}
+	return false, nil
+}
+
+// OTLPSeverity 日志级别对应的 OTLP SeverityNumber
+//
+// 自定义日志级别按其 syslog 严重程度映射
+func OTLPSeverity(level Level) int {
+	switch level {
+	case TRACE:
+		return 1
+	case DEBUG:
+		return 5
+	case INFO:
+		return 9
+	case WARN:
+		return 13
+	case ERROR:
+		return 17
+	case FATAL:
+		return 21
+	}
+	switch SyslogSeverity(level) {
+	case 7:
+		return 5 // DEBUG
+	case 6:
+		return 9 // INFO
+	case 5:
+		return 10 // INFO2
+	case 4:
+		return 13 // WARN
+	case 3:
+		return 17 // ERROR
+	case 2:
+		return 21 // FATAL
+	case 1:
+		return 22 // FATAL2
+	}
+	return 23 // FATAL3
+}
+
+// newOTLPRecord 将日志转换为 OTLP LogRecord
+func newOTLPRecord(info *LogInfo) otlpRecord {
+	record := otlpRecord{
+		observed:       uint64(time.Now().UnixNano()),
+		severityNumber: OTLPSeverity(info.Level),
+		severityText:   GetLevelName(info.Level),
+		body:           info.Body,
+	}
+	if !info.Time.IsZero() {
+		record.time = uint64(info.Time.UnixNano())
+	}
+	if info.Tag != "" {
+		record.attrs = append(record.attrs, otlpAttr{key: "gog.tag", value: info.Tag})
+	}
+	if info.File != "" {
+		record.attrs = append(record.attrs,
+			otlpAttr{key: "code.filepath", value: info.File},
+			otlpAttr{key: "code.lineno", value: int64(info.Line)},
+			otlpAttr{key: "code.function", value: info.Func},
+		)
+	}
+	if info.Goroutine > 0 {
+		record.attrs = append(record.attrs, otlpAttr{key: "thread.id", value: int64(info.Goroutine)})
+	}
+	if len(info.Stack) > 0 {
+		record.attrs = append(record.attrs, otlpAttr{key: "code.stacktrace", value: stackString(info.Stack, "")})
+	}
+
+	fields := make([]Field, 0, len(info.Fields))
+	for _, field := range info.Fields {
+		switch field.Key {
+		case "trace_id", "traceId", "traceID":
+			if id, err := hex.DecodeString(field.ValueString()); err == nil && len(id) == 16 {
+				record.traceID = id
+				continue
+			}
+		case "span_id", "spanId", "spanID":
+			if id, err := hex.DecodeString(field.ValueString()); err == nil && len(id) == 8 {
+				record.spanID = id
+				continue
+			}
+		}
+		fields = append(fields, field)
+	}
+	record.attrs = append(record.attrs, otlpAttrs(fields)...)
+	return record
+}
+
+// otlpAttrs 将字段转换为 OTLP 属性
+func otlpAttrs(fields []Field) []otlpAttr {
+	attrs := make([]otlpAttr, 0, len(fields))
+	for _, field := range fields {
+		attr := otlpAttr{key: field.Key}
+		switch field.Type {
+		case IntType, DurationType:
+			attr.value = field.Integer
+		case UintType:
+			if uint64(field.Integer) > math.MaxInt64 {
+				attr.value = field.ValueString()
+			} else {
+				attr.value = field.Integer
+			}
+		case FloatType:
+			attr.value = math.Float64frombits(uint64(field.Integer))
+		case BoolType:
+			attr.value = field.Integer == 1
+		default:
+			attr.value = field.ValueString()
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+// encodeOTLPJSON 按 OTLP/JSON 编码 ExportLogsServiceRequest
+func encodeOTLPJSON(resource []otlpAttr, records []otlpRecord) []byte {
+	enc := getJSONEncoder()
+	defer putJSONEncoder(enc)
+
+	enc.openObject()
+	enc.key("resourceLogs")
+	enc.openArray()
+	enc.openObject()
+	enc.key("resource")
+	enc.openObject()
+	appendOTLPJSONAttrs(enc, resource)
+	enc.closeObject()
+	enc.key("scopeLogs")
+	enc.openArray()
+	enc.openObject()
+	enc.key("scope")
+	enc.openObject()
+	enc.key("name")
+	enc.appendString("gog")
+	enc.closeObject()
+	enc.key("logRecords")
+	enc.openArray()
+	for i := range records {
+		record := &records[i]
+		enc.openObject()
+		// 64 位整数在 OTLP/JSON 中以字符串表示
+		enc.key("timeUnixNano")
+		enc.appendString(fmt.Sprint(record.time))
+		enc.key("observedTimeUnixNano")
+		enc.appendString(fmt.Sprint(record.observed))
+		enc.key("severityNumber")
+		enc.appendInt(int64(record.severityNumber))
+		enc.key("severityText")
+		enc.appendString(record.severityText)
+		enc.key("body")
+		enc.openObject()
+		enc.key("stringValue")
+		enc.appendString(record.body)
+		enc.closeObject()
+		appendOTLPJSONAttrs(enc, record.attrs)
+		if record.traceID != nil {
+			enc.key("traceId")
+			enc.appendString(hex.EncodeToString(record.traceID))
+		}
+		if record.spanID != nil {
+			enc.key("spanId")
+			enc.appendString(hex.EncodeToString(record.spanID))
+		}
+		enc.closeObject()
+	}
+	enc.closeArray()
+	enc.closeObject()
+	enc.closeArray()
+	enc.closeObject()
+	enc.closeArray()
+	enc.closeObject()
+
+	return append([]byte{}, enc.buf...)
+}
+
+// appendOTLPJSONAttrs 写入 OTLP/JSON 属性列表
+func appendOTLPJSONAttrs(enc *jsonEncoder, attrs []otlpAttr) {
+	enc.key("attributes")
+	enc.openArray()
+	for _, attr := range attrs {
+		enc.openObject()
+		enc.key("key")
+		enc.appendString(attr.key)
+		enc.key("value")
+		enc.openObject()
+		switch v := attr.value.(type) {
+		case int64:
+			enc.key("intValue")
+			enc.appendString(fmt.Sprint(v))
+		case float64:
+			enc.key("doubleValue")
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				enc.appendString(fmt.Sprint(v))
+			} else {
+				enc.appendFloat(v, 64)
+			}
+		case bool:
+			enc.key("boolValue")
+			enc.appendBool(v)
+		default:
+			enc.key("stringValue")
+			enc.appendString(fmt.Sprint(v))
+		}
+		enc.closeObject()
+		enc.closeObject()
+	}
+	enc.closeArray()
+}
+
+// protoBuffer 手工编码 protobuf 消息
+type protoBuffer struct {
+	buf []byte
+}
+
+// varint 写入变长整数
+func (pb *protoBuffer) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	pb.buf = append(pb.buf, b[:binary.PutUvarint(b[:], v)]...)
+}
+
+// tag 写入字段编号和类型
+func (pb *protoBuffer) tag(field int, wireType int) {
+	pb.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+// uint 写入 varint 类型字段
+func (pb *protoBuffer) uint(field int, v uint64) {
+	pb.tag(field, 0)
+	pb.varint(v)
+}
+
+// fixed64 写入 fixed64 类型字段
+func (pb *protoBuffer) fixed64(field int, v uint64) {
+	pb.tag(field, 1)
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	pb.buf = append(pb.buf, b[:]...)
+}
+
+// bytes 写入 length-delimited 类型字段
+func (pb *protoBuffer) bytes(field int, data []byte) {
+	pb.tag(field, 2)
+	pb.varint(uint64(len(data)))
+	pb.buf = append(pb.buf, data...)
+}
+
+// string 写入字符串字段，protobuf 要求字符串为合法的 UTF-8
+func (pb *protoBuffer) string(field int, s string) {
+	s = strings.ToValidUTF8(s, "\ufffd")
+	pb.tag(field, 2)
+	pb.varint(uint64(len(s)))
+	pb.buf = append(pb.buf, s...)
+}
+
+// message 写入嵌套消息字段
+func (pb *protoBuffer) message(field int, fn func(sub *protoBuffer)) {
+	var sub protoBuffer
+	fn(&sub)
+	pb.bytes(field, sub.buf)
+}
+
+// encodeOTLPProtobuf 按 OTLP/protobuf 编码 ExportLogsServiceRequest
+func encodeOTLPProtobuf(resource []otlpAttr, records []otlpRecord) []byte {
+	var pb protoBuffer
+	// ExportLogsServiceRequest.resource_logs
+	pb.message(1, func(rl *protoBuffer) {
+		// ResourceLogs.resource
+		rl.message(1, func(res *protoBuffer) {
+			appendOTLPProtoAttrs(res, 1, resource)
+		})
+		// ResourceLogs.scope_logs
+		rl.message(2, func(sl *protoBuffer) {
+			// ScopeLogs.scope
+			sl.message(1, func(scope *protoBuffer) {
+				scope.string(1, "gog")
+			})
+			for i := range records {
+				record := &records[i]
+				// ScopeLogs.log_records
+				sl.message(2, func(lr *protoBuffer) {
+					lr.fixed64(1, record.time)
+					lr.uint(2, uint64(record.severityNumber))
+					lr.string(3, record.severityText)
+					lr.message(5, func(body *protoBuffer) {
+						body.string(1, record.body)
+					})
+					appendOTLPProtoAttrs(lr, 6, record.attrs)
+					if record.traceID != nil {
+						lr.bytes(9, record.traceID)
+					}
+					if record.spanID != nil {
+						lr.bytes(10, record.spanID)
+					}
+					lr.fixed64(11, record.observed)
+				})
+			}
+		})
+	})
+	return pb.buf
+}
+
+// appendOTLPProtoAttrs 写入 KeyValue 列表
+func appendOTLPProtoAttrs(pb *protoBuffer, field int, attrs []otlpAttr) {
+	for _, attr := range attrs {
+		pb.message(field, func(kv *protoBuffer) {
+			kv.string(1, attr.key)
+			// AnyValue
+			kv.message(2, func(value *protoBuffer) {
+				switch v := attr.value.(type) {
+				case int64:
+					value.uint(3, uint64(v))
+				case float64:
+					value.fixed64(4, math.Float64bits(v))
+				case bool:
+					if v {
+						value.uint(2, 1)
+					} else {
+						value.uint(2, 0)
+					}
+				default:
+					value.string(1, fmt.Sprint(v))
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-22 10:30
+// version: 1.0.0
+// desc   : OpenTelemetry OTLP/HTTP 日志导出输出器
+
+package gog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOTLPWriter(t *testing.T) {
+	type request struct {
+		contentType string
+		body        []byte
+	}
+	requests := make(chan request, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{contentType: r.Header.Get("Content-Type"), body: body}
+	}))
+	defer server.Close()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	info := &LogInfo{
+		Tag:    "db",
+		Time:   time.Unix(1, 5),
+		Level:  WARN,
+		Body:   "slow query",
+		File:   "db.go",
+		Line:   42,
+		Fields: []Field{String("trace_id", traceID), String("span_id", "00f067aa0ba902b7"), Int("rows", 3)},
+	}
+
+	ow := NewOTLPWriter(server.URL).BatchSize(2).Resource(String("service.name", "order"))
+	_, _ = ow.Write(info, nil)
+	_, _ = ow.Write(info, nil)
+	req := <-requests
+	if req.contentType != "application/json" {
+		t.Fatalf("content type = %q", req.contentType)
+	}
+	var payload struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []struct {
+					TimeUnixNano   string `json:"timeUnixNano"`
+					SeverityNumber int    `json:"severityNumber"`
+					SeverityText   string `json:"severityText"`
+					Body           struct {
+						StringValue string `json:"stringValue"`
+					} `json:"body"`
+					Attributes []struct {
+						Key   string                 `json:"key"`
+						Value map[string]interface{} `json:"value"`
+					} `json:"attributes"`
+					TraceID string `json:"traceId"`
+					SpanID  string `json:"spanId"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("invalid json: %v\n%s", err, req.body)
+	}
+	records := payload.ResourceLogs[0].ScopeLogs[0].LogRecords
+	if len(records) != 2 {
+		t.Fatalf("records = %d, want 2", len(records))
+	}
+	record := records[0]
+	if record.TimeUnixNano != "1000000005" || record.SeverityNumber != 13 || record.SeverityText != "WARN" ||
+		record.Body.StringValue != "slow query" || record.TraceID != traceID || record.SpanID != "00f067aa0ba902b7" {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+	attrs := make(map[string]map[string]interface{})
+	for _, attr := range record.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	if attrs["gog.tag"]["stringValue"] != "db" || attrs["rows"]["intValue"] != "3" || attrs["trace_id"] != nil {
+		t.Fatalf("unexpected attributes: %v", attrs)
+	}
+
+	ow.Encoding(OTLPProtobuf)
+	_, _ = ow.Write(info, nil)
+	if err := ow.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req = <-requests
+	id, _ := hex.DecodeString(traceID)
+	if req.contentType != "application/x-protobuf" || !bytes.Contains(req.body, []byte("slow query")) || !bytes.Contains(req.body, id) {
+		t.Fatalf("unexpected protobuf request: %q %x", req.contentType, req.body)
+	}
+}
+
+func TestOTLPWriterNonBlocking(t *testing.T) {
+	release := make(chan struct{})
+	var received int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		atomic.AddInt64(&received, 1)
+	}))
+	defer server.Close()
+
+	ow := NewOTLPWriter(server.URL).BatchSize(1).QueueSize(1).FlushInterval(0)
+	info := &LogInfo{Time: time.Now(), Level: INFO, Body: "blocked"}
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if _, err := ow.Write(info, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 采集端阻塞时写入不等待网络请求
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("writes blocked for %v", elapsed)
+	}
+	// 一批正在导出，一批排队，其余丢弃
+	if dropped := ow.Dropped(); dropped < 8 {
+		t.Fatalf("dropped = %d, want >= 8", dropped)
+	}
+
+	close(release)
+	if err := ow.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := uint64(atomic.LoadInt64(&received)) + ow.Dropped(); got != 10 {
+		t.Fatalf("received + dropped = %d, want 10", got)
+	}
+	if _, err := ow.Write(info, nil); err == nil {
+		t.Fatal("write after close should fail")
+	}
+}
+
+func TestOTLPWriterRetry(t *testing.T) {
+	var attempts int64
+	status := int64(http.StatusServiceUnavailable)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 前两次返回 status，之后成功
+		if atomic.AddInt64(&attempts, 1) <= 2 {
+			w.WriteHeader(int(atomic.LoadInt64(&status)))
+		}
+	}))
+	defer server.Close()
+
+	info := &LogInfo{Time: time.Now(), Level: INFO, Body: "retry"}
+	ow := NewOTLPWriter(server.URL).FlushInterval(0).Retry(3, time.Millisecond)
+	_, _ = ow.Write(info, nil)
+	if err := ow.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt64(&attempts) != 3 || ow.Dropped() != 0 {
+		t.Fatalf("attempts = %d, dropped = %d", attempts, ow.Dropped())
+	}
+
+	// 4xx 不重试，重试次数用完后计入丢弃
+	atomic.StoreInt64(&attempts, 0)
+	atomic.StoreInt64(&status, http.StatusBadRequest)
+	_, _ = ow.Write(info, nil)
+	_, _ = ow.Write(info, nil)
+	if err := ow.Flush(); err == nil {
+		t.Fatal("bad request should fail")
+	}
+	if atomic.LoadInt64(&attempts) != 1 || ow.Dropped() != 2 {
+		t.Fatalf("attempts = %d, dropped = %d", attempts, ow.Dropped())
+	}
+
+	atomic.StoreInt64(&attempts, -10)
+	atomic.StoreInt64(&status, http.StatusBadGateway)
+	_, _ = ow.Write(info, nil)
+	if err := ow.Flush(); err == nil {
+		t.Fatal("exhausted retries should fail")
+	}
+	if atomic.LoadInt64(&attempts) != -6 || ow.Dropped() != 3 {
+		t.Fatalf("attempts = %d, dropped = %d", attempts, ow.Dropped())
+	}
+	if err := ow.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
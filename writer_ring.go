@@ -0,0 +1,256 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 10:12
+// version: 1.0.0
+// desc   : 内存环形缓冲输出器
+
+package gog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// RingSizeDefault 环形缓冲默认容量
+	RingSizeDefault = 2000
+)
+
+// RingWriter 内存环形缓冲输出器
+//
+// 只在内存中保留最近的 N 条日志记录，便于故障发生时现场查询
+type RingWriter struct {
+	mu        sync.RWMutex
+	records   []LogInfo // 环形存储
+	next      int       // 下一条记录写入的位置
+	full      bool      // 是否已写满一圈
+	Formatter Formatter // 文本导出时使用的格式化
+}
+
+// RingQuery 环形缓冲查询条件，零值字段表示不限制
+type RingQuery struct {
+	Level    Level     // 最低日志级别
+	Tag      string    // 标签，完全匹配
+	Since    time.Time // 起始时间（包含）
+	Until    time.Time // 截止时间（不包含）
+	Contains string    // 日志详情中包含的子串
+	Limit    int       // 最多返回的条数，超出时保留最新的记录
+}
+
+// NewRingWriter 创建环形缓冲输出器对象
+//
+// size <= 0 时使用默认容量 RingSizeDefault
+func NewRingWriter(size int) *RingWriter {
+	if size <= 0 {
+		size = RingSizeDefault
+	}
+	return &RingWriter{
+		records:   make([]LogInfo, size),
+		Formatter: NewNormalFormatter(),
+	}
+}
+
+// Write 保存日志记录
+func (rw *RingWriter) Write(info *LogInfo, data []byte) (n int, err error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.records[rw.next] = *info
+	rw.next++
+	if rw.next == len(rw.records) {
+		rw.next = 0
+		rw.full = true
+	}
+	return len(data), nil
+}
+
+// Close 关闭输出器
+func (rw *RingWriter) Close() error {
+	return nil
+}
+
+// Len 当前保存的记录条数
+func (rw *RingWriter) Len() int {
+	rw.mu.RLock()
+	defer rw.mu.RUnlock()
+	if rw.full {
+		return len(rw.records)
+	}
+	return rw.next
+}
+
+// Reset 清空所有记录
+func (rw *RingWriter) Reset() {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.records = make([]LogInfo, len(rw.records))
+	rw.next = 0
+	rw.full = false
+}
+
+// Records 获取全部记录，按时间先后排列
+func (rw *RingWriter) Records() []LogInfo {
+	return rw.Query(RingQuery{})
+}
+
+// Query 按条件查询记录，按时间先后排列
+func (rw *RingWriter) Query(query RingQuery) []LogInfo {
+	rw.mu.RLock()
+	defer rw.mu.RUnlock()
+
+	result := make([]LogInfo, 0)
+	start, count := 0, rw.next
+	if rw.full {
+		start, count = rw.next, len(rw.records)
+	}
+	for i := 0; i < count; i++ {
+		info := rw.records[(start+i)%len(rw.records)]
+		if query.match(&info) {
+			result = append(result, info)
+		}
+	}
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[len(result)-query.Limit:]
+	}
+	return result
+}
+
+// Handler 导出记录的 http 处理器
+//
+// 支持的查询参数：level、tag、since、until（RFC3339 格式）、q（子串）、limit，
+// 以及 format=json 导出 json 数组，默认导出文本
+func (rw *RingWriter) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, err := parseRingQuery(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		records := rw.Query(query)
+
+		if strings.EqualFold(r.URL.Query().Get("format"), "json") {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			logs := make([]ringJSONLog, 0, len(records))
+			for i := range records {
+				logs = append(logs, newRingJSONLog(&records[i]))
+			}
+			_ = json.NewEncoder(w).Encode(logs)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		ftr := rw.Formatter
+		if ftr == nil {
+			ftr = NewNormalFormatter()
+		}
+		for i := range records {
+			data, err := ftr.Format(records[i].Level, GetLevelName(records[i].Level), &records[i])
+			if err != nil {
+				continue
+			}
+			_, _ = w.Write(data)
+		}
+	})
+}
+
+// match 判断记录是否满足查询条件
+func (query *RingQuery) match(info *LogInfo) bool {
+	if !info.Level.AtLeast(query.Level) {
+		return false
+	}
+	if query.Tag != "" && info.Tag != query.Tag {
+		return false
+	}
+	if !query.Since.IsZero() && info.Time.Before(query.Since) {
+		return false
+	}
+	if !query.Until.IsZero() && !info.Time.Before(query.Until) {
+		return false
+	}
+	if query.Contains != "" && !strings.Contains(info.Body, query.Contains) {
+		return false
+	}
+	return true
+}
+
+// parseRingQuery 从 http 请求中解析查询条件
+func parseRingQuery(r *http.Request) (RingQuery, error) {
+	var (
+		query  RingQuery
+		err    error
+		values = r.URL.Query()
+	)
+	if lvl := values.Get("level"); lvl != "" {
+		if query.Level, err = ParseLevelStrict(lvl); err != nil {
+			return query, err
+		}
+	}
+	query.Tag = values.Get("tag")
+	query.Contains = values.Get("q")
+	if since := values.Get("since"); since != "" {
+		if query.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return query, err
+		}
+	}
+	if until := values.Get("until"); until != "" {
+		if query.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return query, err
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil {
+			return query, err
+		}
+	}
+	return query, nil
+}
+
+// 导出 json 时的记录结构
+type ringJSONLog struct {
+	Tag    string                 `json:"tag,omitempty"`
+	Time   time.Time              `json:"time"`
+	Level  string                 `json:"level"`
+	File   string                 `json:"file"`
+	Line   int                    `json:"line"`
+	Func   string                 `json:"func"`
+	Body   string                 `json:"body"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	Stack  []StackFrame           `json:"stack,omitempty"`
+}
+
+func newRingJSONLog(info *LogInfo) ringJSONLog {
+	log := ringJSONLog{
+		Tag:   info.Tag,
+		Time:  info.Time,
+		Level: GetLevelName(info.Level),
+		File:  info.File,
+		Line:  info.Line,
+		Func:  info.Func,
+		Body:  info.Body,
+		Stack: info.Stack,
+	}
+	if len(info.Fields) > 0 {
+		log.Fields = make(map[string]interface{}, len(info.Fields))
+		for _, field := range info.Fields {
+			log.Fields[field.Key] = field.Value()
+		}
+	}
+	return log
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 yhyzgn gog
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// author : 颜洪毅
+// e-mail : yhyzgn@gmail.com
+// time   : 2026-10-19 10:12
+// version: 1.0.0
+// desc   : 内存环形缓冲输出器
+
+package gog
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRingWriter(t *testing.T) {
+	rw := NewRingWriter(3)
+	now := time.Now()
+	for i, lvl := range []Level{DEBUG, INFO, WARN, ERROR} {
+		info := &LogInfo{Tag: "db", Time: now.Add(time.Duration(i) * time.Second), Level: lvl, Body: GetLevelName(lvl)}
+		_, _ = rw.Write(info, nil)
+	}
+
+	if rw.Len() != 3 {
+		t.Fatalf("len = %d, want 3", rw.Len())
+	}
+	if records := rw.Records(); records[0].Level != INFO || records[2].Level != ERROR {
+		t.Fatalf("unexpected order: %v", records)
+	}
+	if records := rw.Query(RingQuery{Level: WARN}); len(records) != 2 {
+		t.Fatalf("level query = %d, want 2", len(records))
+	}
+	if records := rw.Query(RingQuery{Contains: "ERR", Since: now.Add(2 * time.Second)}); len(records) != 1 {
+		t.Fatalf("contains query = %d, want 1", len(records))
+	}
+
+	rec := httptest.NewRecorder()
+	rw.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/?tag=db&level=warn&format=json", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `"level":"ERROR"`) || strings.Contains(body, `"level":"INFO"`) {
+		t.Fatalf("unexpected json dump: %s", body)
+	}
+}